@@ -2,71 +2,243 @@ package mysql
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
 type (
+	// Node is a node of AST which knows where it is in the source. Pos
+	// returns the position of the first character of the node, and End
+	// returns the position just after the node. Both are zero for a node
+	// which isn't made by the parser.
+	Node interface {
+		Pos() Position
+		End() Position
+	}
+
 	Statement interface {
+		Node
 		statement()
 		ToQuery() string
+		versionGates() *VersionGates
 	}
 
 	Identifier interface {
+		Node
 		identifier()
 	}
 
 	AlterSpecification interface {
+		Node
 		alterspecification()
 		ToQuery() string
 	}
 
 	ColumnDefinition struct {
+		Span
+		VersionGates
 		DataTypeDefinition DataTypeDefinition
 		Nullable           bool
 		AutoIncrement      bool
 		Default            DefaultDefinition
+		Generated          Expression
+		Stored             bool
 	}
 
 	DataTypeDefinition interface {
+		Node
 		data_type_definition()
 		ToQuery() string
 	}
 
 	CreateDefinition interface {
+		Node
 		create_definition()
 		ToQuery() string
 	}
 
 	DefaultDefinition interface {
+		Node
 		default_definition()
 		ToQuery() string
 	}
+
+	SetAssignment interface {
+		Node
+		set_assignment()
+		ToQuery() string
+	}
+
+	Expression interface {
+		Node
+		expression()
+		ToQuery() string
+	}
+
+	TableOption interface {
+		Node
+		table_option()
+		versionGate() *VersionGate
+		ToQuery() string
+	}
+
+	TableReference interface {
+		Node
+		table_reference()
+		ToQuery() string
+	}
+
+	// QueryExpression is a statement which can be used as a subquery:
+	// SelectStatement, SetOperationStatement or ParenQueryExpression.
+	QueryExpression interface {
+		Statement
+		toQuery() string
+	}
 )
 
+// Span is the range of a node in the source, which is embedded into nodes
+// to implement Node.
+type Span struct {
+	start Position
+	end   Position
+}
+
+func (x Span) Pos() Position {
+	return x.start
+}
+
+func (x Span) End() Position {
+	return x.end
+}
+
+// VersionGate is the executable comment such as "/*!50100 ... */" which
+// encloses a node inside a statement, which is embedded into nodes which
+// can be enclosed. Enclosed is false if the node is not enclosed. Version
+// is zero if the comment doesn't have version number, and MariaDB is true
+// for "/*M!NNNNN ... */".
+type VersionGate struct {
+	Enclosed bool
+	Version  uint
+	MariaDB  bool
+}
+
+func (x *VersionGate) versionGate() *VersionGate {
+	return x
+}
+
+// wrap encloses query in the executable comment if the node is enclosed.
+func (x VersionGate) wrap(query string) string {
+	if !x.Enclosed {
+		return query
+	}
+	prefix := "/*!"
+	if x.MariaDB {
+		prefix = "/*M!"
+	}
+	if x.Version == 0 {
+		return prefix + " " + query + " */"
+	}
+	return fmt.Sprintf("%s%d %s */", prefix, x.Version, query)
+}
+
+// PartialVersionGate is the executable comment inside a statement which
+// encloses a part of a node rather than a whole node, such as "NOT NULL" of
+// "id INT /*!50100 NOT NULL */". Span is the range of the tokens in the node
+// which are enclosed by the comment.
+type PartialVersionGate struct {
+	Span
+	VersionGate
+}
+
+// VersionGates is the partial version gates in a node, which is embedded
+// into statements and column definitions. ToQuery generates the enclosed
+// tokens without the comment.
+type VersionGates struct {
+	Gates []PartialVersionGate
+}
+
+func (x *VersionGates) versionGates() *VersionGates {
+	return x
+}
+
 type (
 	DropTableStatement struct {
+		Span
+		VersionGates
+		IfExists   bool
 		TableNames []TableNameIdentifier
 	}
 	DropDatabaseStatement struct {
+		Span
+		VersionGates
+		IfExists     bool
 		DatabaseName DatabaseNameIdentifier
 	}
+	// CreateDatabaseStatement is CREATE DATABASE. IfNotExistsGate is the
+	// executable comment which encloses IF NOT EXISTS such as
+	// "/*!32312 IF NOT EXISTS*/" of mysqldump. Options are DEFAULT CHARACTER
+	// SET, COLLATE and ENCRYPTION.
 	CreateDatabaseStatement struct {
+		Span
+		VersionGates
+		IfNotExists     bool
+		IfNotExistsGate VersionGate
+		DatabaseName    DatabaseNameIdentifier
+		Options         []TableOption
+	}
+	// UseStatement is USE db.
+	UseStatement struct {
+		Span
+		VersionGates
 		DatabaseName DatabaseNameIdentifier
 	}
 	AlterTableStatement struct {
+		Span
+		VersionGates
 		TableName           TableNameIdentifier
 		AlterSpecifications []AlterSpecification
 	}
 
 	CreateTableStatement struct {
+		Span
+		VersionGates
 		TableName         TableNameIdentifier
 		CreateDefinitions []CreateDefinition
 		TableOptions      []TableOption
+		PartitionOptions  *PartitionOptions
 	}
 
 	CommentStatement struct {
+		Span
+		VersionGates
 		Content string
 	}
+
+	// UnparsedStatement is a statement which ParseWithRecovery failed to
+	// parse. Text is the raw text of the statement without the delimiter.
+	UnparsedStatement struct {
+		Span
+		VersionGates
+		Text string
+	}
+
+	// ExecutableCommentStatement is a statement enclosed by executable comment
+	// such as "/*!40101 SET NAMES utf8 */;". Version is zero if the comment
+	// doesn't have version number. MariaDB is true for the comment which is
+	// executed only by MariaDB such as "/*M!100100 ... */".
+	ExecutableCommentStatement struct {
+		Span
+		VersionGates
+		Version   uint
+		MariaDB   bool
+		Statement Statement
+	}
+
+	SetStatement struct {
+		Span
+		VersionGates
+		Assignments []SetAssignment
+	}
 )
 
 func (x *DropTableStatement) statement() {}
@@ -75,103 +247,390 @@ func (x *DropTableStatement) ToQuery() string {
 	for _, table := range x.TableNames {
 		tableNames = append(tableNames, table.ToQuery())
 	}
-	return "DROP TABLE " + strings.Join(tableNames, ", ") + ";"
+	result := "DROP TABLE "
+	if x.IfExists {
+		result += "IF EXISTS "
+	}
+	return result + strings.Join(tableNames, ", ") + ";"
 }
 
 func (x *DropDatabaseStatement) statement() {}
 func (x *DropDatabaseStatement) ToQuery() string {
-	return "DROP DATABASE " + x.DatabaseName.ToQuery() + ";"
+	result := "DROP DATABASE "
+	if x.IfExists {
+		result += "IF EXISTS "
+	}
+	return result + x.DatabaseName.ToQuery() + ";"
 }
 func (x *CreateDatabaseStatement) statement() {}
 func (x *CreateDatabaseStatement) ToQuery() string {
-	return "CREATE DATABASE " + x.DatabaseName.ToQuery() + ";"
+	result := "CREATE DATABASE "
+	if x.IfNotExists {
+		result += x.IfNotExistsGate.wrap("IF NOT EXISTS") + " "
+	}
+	result += x.DatabaseName.ToQuery()
+	for _, option := range x.Options {
+		result += " " + option.ToQuery()
+	}
+	return result + ";"
+}
+func (x *UseStatement) statement() {}
+func (x *UseStatement) ToQuery() string {
+	return "USE " + x.DatabaseName.ToQuery() + ";"
 }
 
 func (x *AlterTableStatement) statement() {}
 func (x *AlterTableStatement) ToQuery() string {
-	var specQueries []string
+	return x.toQuery(false)
+}
+func (x *AlterTableStatement) toQuery(redact bool) string {
+	specQueries := ""
 	for _, spec := range x.AlterSpecifications {
-		specQueries = append(specQueries, spec.ToQuery())
+		if specQueries != "" {
+			// PARTITION BY is not separated by comma.
+			if _, isPartitionBy := spec.(*AlterSpecificationPartitionBy); isPartitionBy {
+				specQueries += " "
+			} else {
+				specQueries += ", "
+			}
+		}
+		if options, ok := spec.(*AlterSpecificationTableOptions); ok {
+			specQueries += joinTableOptions(options.TableOptions, redact)
+		} else {
+			specQueries += spec.ToQuery()
+		}
 	}
-	return "ALTER TABLE " + x.TableName.ToQuery() + " " + strings.Join(specQueries, ", ") + ";"
+	return "ALTER TABLE " + x.TableName.ToQuery() + " " + specQueries + ";"
 }
 func (x *CreateTableStatement) statement() {}
 func (x *CreateTableStatement) ToQuery() string {
-	var options []string
-	for _, option := range x.TableOptions {
-		options = append(options, option.ToQuery())
-	}
+	return x.toQuery(false)
+}
+func (x *CreateTableStatement) toQuery(redact bool) string {
 	var defs []string
 	for _, def := range x.CreateDefinitions {
 		defs = append(defs, def.ToQuery())
 	}
-	return "CREATE TABLE " + x.TableName.ToQuery() + " (\n\t" + strings.Join(defs, ",\n\t") + "\n) " + strings.Join(options, " ") + ";"
+	result := "CREATE TABLE " + x.TableName.ToQuery() + " (\n\t" + strings.Join(defs, ",\n\t") + "\n) " + joinTableOptions(x.TableOptions, redact)
+	if x.PartitionOptions != nil {
+		result += "\n" + x.PartitionOptions.ToQuery()
+	}
+	return result + ";"
+}
+
+// joinTableOptions generates table options separated by spaces. The
+// values of PASSWORD options are replaced if redact is true.
+func joinTableOptions(tableOptions []TableOption, redact bool) string {
+	var options []string
+	for _, option := range tableOptions {
+		if opt, ok := option.(*TableOptionString); ok {
+			options = append(options, opt.toQuery(redact))
+		} else {
+			options = append(options, option.ToQuery())
+		}
+	}
+	return strings.Join(options, " ")
+}
+
+// Engine returns the value of ENGINE option. It returns empty string if the option is not specified.
+// When the option is specified more than once, the last one wins like MySQL.
+func (x *CreateTableStatement) Engine() string {
+	return x.nameOption("ENGINE")
+}
+
+// Charset returns the value of DEFAULT CHARACTER SET option.
+func (x *CreateTableStatement) Charset() string {
+	return x.nameOption("DEFAULT CHARACTER SET")
+}
+
+// Collation returns the value of COLLATE option.
+func (x *CreateTableStatement) Collation() string {
+	return x.nameOption("COLLATE")
+}
+
+// RowFormat returns the value of ROW_FORMAT option.
+func (x *CreateTableStatement) RowFormat() string {
+	return x.nameOption("ROW_FORMAT")
+}
+
+// Comment returns the value of COMMENT option.
+func (x *CreateTableStatement) Comment() string {
+	var result string
+	for _, option := range x.TableOptions {
+		if opt, ok := option.(*TableOptionString); ok && opt.Key == "COMMENT" {
+			result = opt.Value
+		}
+	}
+	return result
+}
+
+// Tablespace returns the name of TABLESPACE option.
+func (x *CreateTableStatement) Tablespace() string {
+	var result string
+	for _, option := range x.TableOptions {
+		if opt, ok := option.(*TableOptionTablespace); ok {
+			result = opt.Name
+		}
+	}
+	return result
+}
+
+// AutoIncrement returns the value of AUTO_INCREMENT option. It returns 0 if the option is not specified.
+func (x *CreateTableStatement) AutoIncrement() uint64 {
+	return x.numberOption("AUTO_INCREMENT")
+}
+
+// AvgRowLength returns the value of AVG_ROW_LENGTH option.
+func (x *CreateTableStatement) AvgRowLength() uint64 {
+	return x.numberOption("AVG_ROW_LENGTH")
+}
+
+// MaxRows returns the value of MAX_ROWS option.
+func (x *CreateTableStatement) MaxRows() uint64 {
+	return x.numberOption("MAX_ROWS")
+}
+
+// MinRows returns the value of MIN_ROWS option.
+func (x *CreateTableStatement) MinRows() uint64 {
+	return x.numberOption("MIN_ROWS")
+}
+
+// KeyBlockSize returns the value of KEY_BLOCK_SIZE option.
+func (x *CreateTableStatement) KeyBlockSize() uint64 {
+	return x.numberOption("KEY_BLOCK_SIZE")
+}
+
+func (x *CreateTableStatement) nameOption(key string) string {
+	var result string
+	for _, option := range x.TableOptions {
+		if opt, ok := option.(*TableOptionName); ok && opt.Key == key {
+			result = opt.Value
+		}
+	}
+	return result
+}
+
+func (x *CreateTableStatement) numberOption(key string) uint64 {
+	var result uint64
+	for _, option := range x.TableOptions {
+		if opt, ok := option.(*TableOptionNumber); ok && opt.Key == key {
+			result = opt.Value
+		}
+	}
+	return result
 }
+
 func (x *CommentStatement) statement() {}
 func (x *CommentStatement) ToQuery() string {
-	return "TODO"
+	return "/*" + x.Content + "*/;"
+}
+func (x *UnparsedStatement) statement() {}
+func (x *UnparsedStatement) ToQuery() string {
+	return x.Text + ";"
+}
+func (x *ExecutableCommentStatement) statement() {}
+func (x *ExecutableCommentStatement) ToQuery() string {
+	return x.wrap(x.Statement.ToQuery())
+}
+func (x *ExecutableCommentStatement) wrap(query string) string {
+	gate := VersionGate{Enclosed: true, Version: x.Version, MariaDB: x.MariaDB}
+	return gate.wrap(strings.TrimSuffix(query, ";")) + ";"
+}
+func (x *SetStatement) statement() {}
+func (x *SetStatement) ToQuery() string {
+	var assignments []string
+	for _, assignment := range x.Assignments {
+		assignments = append(assignments, assignment.ToQuery())
+	}
+	return "SET " + strings.Join(assignments, ", ") + ";"
 }
 
 type (
 	TableNameIdentifier struct {
+		Span
 		Name     string
 		Database string
 	}
 	DatabaseNameIdentifier struct {
+		Span
 		Name string
 	}
 	ColumnNameIdentifier struct {
+		Span
 		Name string
 	}
 	IndexNameIdentifier struct {
+		Span
+		Name string
+	}
+	PartitionNameIdentifier struct {
+		Span
 		Name string
 	}
+	// AccountNameIdentifier is an account or role name such as 'user'@'host'.
+	// Host is empty if it is omitted.
+	AccountNameIdentifier struct {
+		Span
+		User        string
+		Host        string
+		CurrentUser bool
+	}
 
 	EngineNameIdentifier struct {
+		Span
 		Name string
 	}
 )
 
+// quoteIdentifier wraps name in backquotes, doubling any backquote it
+// contains so that the result is always read back as a single identifier.
+func quoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+// stringEscaper escapes the characters which can not appear as they are
+// in a string literal, assuming that NO_BACKSLASH_ESCAPES is disabled.
+var stringEscaper = strings.NewReplacer("\\", "\\\\", "'", "''", "\x00", "\\0", "\n", "\\n", "\r", "\\r", "\x1a", "\\Z")
+
+// quoteString wraps value in single quotes with escaping.
+func quoteString(value string) string {
+	return "'" + stringEscaper.Replace(value) + "'"
+}
+
+// quoteName returns name as it is if it is a plain word, or quoted by
+// quote otherwise. It is used for names such as engines and character sets,
+// which are usually written without quotes.
+func quoteName(name string, quote func(string) string) string {
+	if isWord(name) && !reservedKeywords[strings.ToUpper(name)] {
+		return name
+	}
+	return quote(name)
+}
+
+// isWord reports whether name consists of ASCII letters, digits, '_' and
+// '$' and doesn't start with a digit.
+func isWord(name string) bool {
+	if name == "" || ('0' <= name[0] && name[0] <= '9') {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		ch := name[i]
+		if !('a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || '0' <= ch && ch <= '9' || ch == '_' || ch == '$') {
+			return false
+		}
+	}
+	return true
+}
+
 func (x *TableNameIdentifier) identifier() {}
 
 func (x *TableNameIdentifier) ToQuery() string {
 	if x.Database == "" {
-		return "`" + x.Name + "`"
+		return quoteIdentifier(x.Name)
 	} else {
-		return fmt.Sprintf("`%s`.`%s`", x.Database, x.Name)
+		return quoteIdentifier(x.Database) + "." + quoteIdentifier(x.Name)
 	}
 }
 
 func (x *DatabaseNameIdentifier) identifier() {}
 func (x *DatabaseNameIdentifier) ToQuery() string {
-	return "`" + x.Name + "`"
+	return quoteIdentifier(x.Name)
 }
 func (x *ColumnNameIdentifier) identifier() {}
 func (x *ColumnNameIdentifier) ToQuery() string {
-	return "`" + x.Name + "`"
+	return quoteIdentifier(x.Name)
 }
 
 func (x *IndexNameIdentifier) identifier() {}
 func (x *IndexNameIdentifier) ToQuery() string {
-	return "`" + x.Name + "`"
+	return quoteIdentifier(x.Name)
+}
+
+func (x *PartitionNameIdentifier) identifier() {}
+func (x *PartitionNameIdentifier) ToQuery() string {
+	return quoteIdentifier(x.Name)
+}
+
+func (x *AccountNameIdentifier) identifier() {}
+func (x *AccountNameIdentifier) ToQuery() string {
+	if x.CurrentUser {
+		return "CURRENT_USER"
+	}
+	if x.Host == "" {
+		return quoteIdentifier(x.User)
+	}
+	return quoteIdentifier(x.User) + "@" + quoteIdentifier(x.Host)
 }
 
 type (
 	AlterSpecificationDropColumn struct {
+		Span
 		ColumnName ColumnNameIdentifier
 	}
 	AlterSpecificationDropIndex struct {
+		Span
 		Name IndexNameIdentifier
 	}
 	AlterSpecificationAddColumn struct {
+		Span
 		ColumnName       ColumnNameIdentifier
 		ColumnDefinition ColumnDefinition
 	}
 	AlterSpecificationAddIndex struct {
+		Span
 		Name    IndexNameIdentifier
 		Columns []ColumnNameIdentifier
 		Unique  bool
 	}
+	// ADD PARTITION (definitions) or ADD PARTITION PARTITIONS number
+	AlterSpecificationAddPartition struct {
+		Span
+		Definitions []PartitionDefinition
+		Partitions  uint
+	}
+	AlterSpecificationDropPartition struct {
+		Span
+		Names []PartitionNameIdentifier
+	}
+	AlterSpecificationTruncatePartition struct {
+		Span
+		Names []PartitionNameIdentifier
+		All   bool
+	}
+	AlterSpecificationCoalescePartition struct {
+		Span
+		Number uint
+	}
+	AlterSpecificationReorganizePartition struct {
+		Span
+		Names       []PartitionNameIdentifier
+		Definitions []PartitionDefinition
+	}
+	AlterSpecificationExchangePartition struct {
+		Span
+		Name              PartitionNameIdentifier
+		TableName         TableNameIdentifier
+		WithoutValidation bool
+	}
+	AlterSpecificationRemovePartitioning struct {
+		Span
+	}
+	AlterSpecificationPartitionBy struct {
+		Span
+		PartitionOptions *PartitionOptions
+	}
+	// AlterSpecificationKeys is ENABLE KEYS or DISABLE KEYS, which mysqldump
+	// puts around the data of a table.
+	AlterSpecificationKeys struct {
+		Span
+		Enable bool
+	}
+	// AlterSpecificationTableOptions is the table options changed by ALTER
+	// TABLE such as "ENGINE=InnoDB ROW_FORMAT=DYNAMIC".
+	AlterSpecificationTableOptions struct {
+		Span
+		TableOptions []TableOption
+	}
 )
 
 func (x *AlterSpecificationDropColumn) alterspecification() {}
@@ -206,9 +665,80 @@ func (x *AlterSpecificationAddIndex) ToQuery() string {
 	return result
 }
 
+func (x *AlterSpecificationAddPartition) alterspecification() {}
+func (x *AlterSpecificationAddPartition) ToQuery() string {
+	if len(x.Definitions) == 0 {
+		return fmt.Sprintf("ADD PARTITION PARTITIONS %d", x.Partitions)
+	}
+	return "ADD PARTITION (" + partitionDefinitionsToQuery(x.Definitions) + ")"
+}
+
+func (x *AlterSpecificationDropPartition) alterspecification() {}
+func (x *AlterSpecificationDropPartition) ToQuery() string {
+	return "DROP PARTITION " + partitionNamesToQuery(x.Names)
+}
+
+func (x *AlterSpecificationTruncatePartition) alterspecification() {}
+func (x *AlterSpecificationTruncatePartition) ToQuery() string {
+	if x.All {
+		return "TRUNCATE PARTITION ALL"
+	}
+	return "TRUNCATE PARTITION " + partitionNamesToQuery(x.Names)
+}
+
+func (x *AlterSpecificationCoalescePartition) alterspecification() {}
+func (x *AlterSpecificationCoalescePartition) ToQuery() string {
+	return fmt.Sprintf("COALESCE PARTITION %d", x.Number)
+}
+
+func (x *AlterSpecificationReorganizePartition) alterspecification() {}
+func (x *AlterSpecificationReorganizePartition) ToQuery() string {
+	return "REORGANIZE PARTITION " + partitionNamesToQuery(x.Names) + " INTO (" + partitionDefinitionsToQuery(x.Definitions) + ")"
+}
+
+func (x *AlterSpecificationExchangePartition) alterspecification() {}
+func (x *AlterSpecificationExchangePartition) ToQuery() string {
+	result := "EXCHANGE PARTITION " + x.Name.ToQuery() + " WITH TABLE " + x.TableName.ToQuery()
+	if x.WithoutValidation {
+		result += " WITHOUT VALIDATION"
+	}
+	return result
+}
+
+func (x *AlterSpecificationRemovePartitioning) alterspecification() {}
+func (x *AlterSpecificationRemovePartitioning) ToQuery() string {
+	return "REMOVE PARTITIONING"
+}
+
+func (x *AlterSpecificationPartitionBy) alterspecification() {}
+func (x *AlterSpecificationPartitionBy) ToQuery() string {
+	return x.PartitionOptions.ToQuery()
+}
+
+func (x *AlterSpecificationTableOptions) alterspecification() {}
+func (x *AlterSpecificationTableOptions) ToQuery() string {
+	return joinTableOptions(x.TableOptions, false)
+}
+
+func (x *AlterSpecificationKeys) alterspecification() {}
+func (x *AlterSpecificationKeys) ToQuery() string {
+	if x.Enable {
+		return "ENABLE KEYS"
+	}
+	return "DISABLE KEYS"
+}
+
 func (x ColumnDefinition) ToQuery() string {
 	result := ""
 	result += x.DataTypeDefinition.ToQuery()
+	if x.Generated != nil {
+		result += " GENERATED ALWAYS AS (" + x.Generated.ToQuery() + ")"
+		if x.Stored {
+			result += " STORED"
+		} else {
+			result += " VIRTUAL"
+		}
+	}
 	if !x.Nullable {
 		result += " NOT NULL"
 	}
@@ -222,15 +752,18 @@ func (x ColumnDefinition) ToQuery() string {
 
 type (
 	DataTypeDefinitionSimple struct {
+		Span
 		Type DataType
 	}
 	DataTypeDefinitionNumber struct {
+		Span
 		Type     DataType
 		Length   uint
 		Unsigned bool
 		Zerofill bool
 	}
 	DataTypeDefinitionFraction struct {
+		Span
 		Type     DataType
 		Length   uint
 		Decimals uint
@@ -238,12 +771,14 @@ type (
 		Zerofill bool
 	}
 	DataTypeDefinitionString struct {
+		Span
 		Type          DataType
 		Length        uint
 		CharsetName   string
 		CollationName string
 	}
 	DataTypeDefinitionTextBlob struct {
+		Span
 		Type          DataType
 		Binary        bool
 		CharsetName   string
@@ -295,10 +830,10 @@ func (x *DataTypeDefinitionString) ToQuery() string {
 		result += fmt.Sprintf("(%d)", x.Length)
 	}
 	if x.CharsetName != "" {
-		result += fmt.Sprintf(" CHARACTER SET %s", x.CharsetName)
+		result += " CHARACTER SET " + quoteName(x.CharsetName, quoteString)
 	}
 	if x.CollationName != "" {
-		result += fmt.Sprintf(" COLLATE %s", x.CollationName)
+		result += " COLLATE " + quoteName(x.CollationName, quoteString)
 	}
 	return result
 }
@@ -310,28 +845,32 @@ func (x *DataTypeDefinitionTextBlob) ToQuery() string {
 		result += " BINARY"
 	}
 	if x.CharsetName != "" {
-		result += fmt.Sprintf(" CHARACTER SET %s", x.CharsetName)
+		result += " CHARACTER SET " + quoteName(x.CharsetName, quoteString)
 	}
 	if x.CollationName != "" {
-		result += fmt.Sprintf(" COLLATE %s", x.CollationName)
+		result += " COLLATE " + quoteName(x.CollationName, quoteString)
 	}
 	return result
 }
 
 type (
 	CreateDefinitionColumn struct {
+		Span
 		ColumnName       ColumnNameIdentifier
 		ColumnDefinition ColumnDefinition
 	}
 	CreateDefinitionPrimaryIndex struct {
+		Span
 		Columns []ColumnNameIdentifier
 	}
 
 	CreateDefinitionUniqueIndex struct {
+		Span
 		Name    IndexNameIdentifier
 		Columns []ColumnNameIdentifier
 	}
 	CreateDefinitionIndex struct {
+		Span
 		Name    IndexNameIdentifier
 		Columns []ColumnNameIdentifier
 	}
@@ -376,16 +915,25 @@ func (x *CreateDefinitionIndex) ToQuery() string {
 
 type (
 	DefaultDefinitionString struct {
+		Span
 		Value string
 	}
 
+	DefaultDefinitionNumber struct {
+		Span
+		Value NumberExpression
+	}
+
 	DefaultDefinitionEmpty struct {
+		Span
 	}
 
 	DefaultDefinitionNull struct {
+		Span
 	}
 
 	DefaultDefinitionCurrentTimestamp struct {
+		Span
 		OnUpdate bool
 	}
 )
@@ -400,7 +948,11 @@ func (x *DefaultDefinitionNull) ToQuery() string {
 }
 func (x *DefaultDefinitionString) default_definition() {}
 func (x *DefaultDefinitionString) ToQuery() string {
-	return "DEFAULT \"" + x.Value + "\""
+	return "DEFAULT " + quoteString(x.Value)
+}
+func (x *DefaultDefinitionNumber) default_definition() {}
+func (x *DefaultDefinitionNumber) ToQuery() string {
+	return "DEFAULT " + x.Value.ToQuery()
 }
 func (x *DefaultDefinitionCurrentTimestamp) default_definition() {}
 func (x *DefaultDefinitionCurrentTimestamp) ToQuery() string {
@@ -411,16 +963,2126 @@ func (x *DefaultDefinitionCurrentTimestamp) ToQuery() string {
 	}
 }
 
-type TableOption struct {
-	Key   string
-	Value string
+type (
+	// TableOptionName is an option whose value is a name such as ENGINE=InnoDB.
+	TableOptionName struct {
+		Span
+		VersionGate
+		Key   string
+		Value string
+	}
+	TableOptionNumber struct {
+		Span
+		VersionGate
+		Key   string
+		Value uint64
+	}
+	// TableOptionString is an option whose value is a quoted string such as COMMENT 'foo'.
+	TableOptionString struct {
+		Span
+		VersionGate
+		Key   string
+		Value string
+	}
+	// TableOptionDefault is an option whose value is DEFAULT such as PACK_KEYS=DEFAULT.
+	TableOptionDefault struct {
+		Span
+		VersionGate
+		Key string
+	}
+	// TableOptionTablespace is TABLESPACE option. Storage is "DISK", "MEMORY" or empty.
+	TableOptionTablespace struct {
+		Span
+		VersionGate
+		Name    string
+		Storage string
+	}
+	TableOptionUnion struct {
+		Span
+		VersionGate
+		TableNames []TableNameIdentifier
+	}
+	// TableOptionFlag is an option without value such as WAIT.
+	TableOptionFlag struct {
+		Span
+		VersionGate
+		Key string
+	}
+)
+
+func (x *TableOptionName) table_option() {}
+func (x *TableOptionName) ToQuery() string {
+	return x.wrap(x.Key + "=" + x.value())
+}
+func (x *TableOptionName) value() string {
+	switch {
+	case x.Key == "DEFAULT CHARACTER SET" || x.Key == "COLLATE":
+		if x.Value == "" {
+			return "DEFAULT"
+		}
+		return quoteName(x.Value, quoteString)
+	case x.Key == "INSERT_METHOD" || x.Key == "ROW_FORMAT" || x.Key == "SECONDARY_ENGINE" && x.Value == "NULL":
+		// the value is a keyword such as DEFAULT.
+		if isWord(x.Value) {
+			return x.Value
+		}
+	}
+	return quoteName(x.Value, quoteIdentifier)
+}
+func (x *TableOptionNumber) table_option() {}
+func (x *TableOptionNumber) ToQuery() string {
+	return x.wrap(x.Key + "=" + strconv.FormatUint(x.Value, 10))
+}
+func (x *TableOptionString) table_option() {}
+func (x *TableOptionString) ToQuery() string {
+	return x.toQuery(false)
+}
+func (x *TableOptionString) toQuery(redact bool) string {
+	if x.Key == "PASSWORD" {
+		return x.wrap(x.Key + " " + quotePassword(x.Value, redact))
+	}
+	return x.wrap(x.Key + " " + quoteString(x.Value))
+}
+func (x *TableOptionDefault) table_option() {}
+func (x *TableOptionDefault) ToQuery() string {
+	return x.wrap(x.Key + "=DEFAULT")
+}
+func (x *TableOptionTablespace) table_option() {}
+func (x *TableOptionTablespace) ToQuery() string {
+	result := "TABLESPACE " + quoteIdentifier(x.Name)
+	if x.Storage != "" {
+		result += " STORAGE " + x.Storage
+	}
+	return x.wrap(result)
+}
+func (x *TableOptionFlag) table_option() {}
+func (x *TableOptionFlag) ToQuery() string {
+	return x.wrap(x.Key)
+}
+func (x *TableOptionUnion) table_option() {}
+func (x *TableOptionUnion) ToQuery() string {
+	var tableNames []string
+	for _, table := range x.TableNames {
+		tableNames = append(tableNames, table.ToQuery())
+	}
+	return x.wrap("UNION=(" + strings.Join(tableNames, ", ") + ")")
 }
 
-func (x *TableOption) ToQuery() string {
-	switch x.Key {
-	case "COMMENT":
-		return x.Key + " " + "\"" + x.Value + "\""
+type (
+	CreateUserStatement struct {
+		Span
+		VersionGates
+		IfNotExists  bool
+		Users        []UserSpecification
+		DefaultRoles []AccountNameIdentifier
+		// "LOCK", "UNLOCK" or empty
+		AccountLock string
+	}
+	AlterUserStatement struct {
+		Span
+		VersionGates
+		IfExists    bool
+		Users       []UserSpecification
+		AccountLock string
+	}
+	DropUserStatement struct {
+		Span
+		VersionGates
+		IfExists bool
+		Users    []AccountNameIdentifier
+	}
+	CreateRoleStatement struct {
+		Span
+		VersionGates
+		IfNotExists bool
+		Roles       []AccountNameIdentifier
+	}
+	DropRoleStatement struct {
+		Span
+		VersionGates
+		IfExists bool
+		Roles    []AccountNameIdentifier
+	}
+	GrantStatement struct {
+		Span
+		VersionGates
+		Privileges      []Privilege
+		Level           PrivilegeLevel
+		Users           []AccountNameIdentifier
+		WithGrantOption bool
+	}
+	GrantRoleStatement struct {
+		Span
+		VersionGates
+		Roles           []AccountNameIdentifier
+		Users           []AccountNameIdentifier
+		WithAdminOption bool
+	}
+	RevokeStatement struct {
+		Span
+		VersionGates
+		Privileges []Privilege
+		Level      PrivilegeLevel
+		Users      []AccountNameIdentifier
+	}
+	// RevokeAllStatement is "REVOKE ALL PRIVILEGES, GRANT OPTION FROM user".
+	RevokeAllStatement struct {
+		Span
+		VersionGates
+		Users []AccountNameIdentifier
+	}
+	RevokeRoleStatement struct {
+		Span
+		VersionGates
+		Roles []AccountNameIdentifier
+		Users []AccountNameIdentifier
+	}
+	// SetDefaultRoleStatement is SET DEFAULT ROLE. It means NONE when All is
+	// false and Roles is empty.
+	SetDefaultRoleStatement struct {
+		Span
+		VersionGates
+		All   bool
+		Roles []AccountNameIdentifier
+		Users []AccountNameIdentifier
+	}
+	// SetPasswordStatement is SET PASSWORD. Account is nil for the current user.
+	SetPasswordStatement struct {
+		Span
+		VersionGates
+		Account  *AccountNameIdentifier
+		Password string
+	}
+
+	UserSpecification struct {
+		Span
+		Account AccountNameIdentifier
+		Auth    *AuthOption
+	}
+	// AuthOption is IDENTIFIED BY or IDENTIFIED WITH clause.
+	AuthOption struct {
+		Span
+		Plugin         string
+		Password       string
+		RandomPassword bool
+		// hashed authentication string of IDENTIFIED WITH plugin AS 'string'
+		AuthString string
+	}
+	// Privilege is a privilege such as SELECT or "SELECT (col1, col2)". Type
+	// is upper case and ALL PRIVILEGES is normalized to ALL.
+	Privilege struct {
+		Span
+		Type    string
+		Columns []ColumnNameIdentifier
+	}
+	// PrivilegeLevel is the target of GRANT. Database and Table are "*" for
+	// wildcards and Database is empty if it is omitted. ObjectType is "TABLE",
+	// "FUNCTION", "PROCEDURE" or empty.
+	PrivilegeLevel struct {
+		Span
+		ObjectType string
+		Database   string
+		Table      string
+	}
+)
+
+// RedactedPassword is printed instead of passwords by RedactedQuery.
+const RedactedPassword = "<secret>"
+
+// RedactedQuery returns the same query as stmt.ToQuery() except that passwords
+// and authentication strings are replaced by RedactedPassword. It is useful
+// to write statements to logs.
+func RedactedQuery(stmt Statement) string {
+	switch x := stmt.(type) {
+	case *CreateUserStatement:
+		return x.toQuery(true)
+	case *AlterUserStatement:
+		return x.toQuery(true)
+	case *SetPasswordStatement:
+		return x.toQuery(true)
+	case *CreateServerStatement:
+		return x.toQuery(true)
+	case *AlterServerStatement:
+		return x.toQuery(true)
+	case *CreateTableStatement:
+		return x.toQuery(true)
+	case *AlterTableStatement:
+		return x.toQuery(true)
+	case *ExecutableCommentStatement:
+		return x.wrap(RedactedQuery(x.Statement))
 	default:
-		return x.Key + "=" + x.Value
+		return stmt.ToQuery()
+	}
+}
+
+func (x *CreateUserStatement) statement() {}
+func (x *CreateUserStatement) ToQuery() string {
+	return x.toQuery(false)
+}
+func (x *CreateUserStatement) toQuery(redact bool) string {
+	result := "CREATE USER "
+	if x.IfNotExists {
+		result += "IF NOT EXISTS "
+	}
+	result += userSpecificationsToQuery(x.Users, redact)
+	if len(x.DefaultRoles) > 0 {
+		result += " DEFAULT ROLE " + accountNamesToQuery(x.DefaultRoles)
+	}
+	if x.AccountLock != "" {
+		result += " ACCOUNT " + x.AccountLock
+	}
+	return result + ";"
+}
+
+func (x *AlterUserStatement) statement() {}
+func (x *AlterUserStatement) ToQuery() string {
+	return x.toQuery(false)
+}
+func (x *AlterUserStatement) toQuery(redact bool) string {
+	result := "ALTER USER "
+	if x.IfExists {
+		result += "IF EXISTS "
+	}
+	result += userSpecificationsToQuery(x.Users, redact)
+	if x.AccountLock != "" {
+		result += " ACCOUNT " + x.AccountLock
+	}
+	return result + ";"
+}
+
+func (x *DropUserStatement) statement() {}
+func (x *DropUserStatement) ToQuery() string {
+	result := "DROP USER "
+	if x.IfExists {
+		result += "IF EXISTS "
+	}
+	return result + accountNamesToQuery(x.Users) + ";"
+}
+
+func (x *CreateRoleStatement) statement() {}
+func (x *CreateRoleStatement) ToQuery() string {
+	result := "CREATE ROLE "
+	if x.IfNotExists {
+		result += "IF NOT EXISTS "
+	}
+	return result + accountNamesToQuery(x.Roles) + ";"
+}
+
+func (x *DropRoleStatement) statement() {}
+func (x *DropRoleStatement) ToQuery() string {
+	result := "DROP ROLE "
+	if x.IfExists {
+		result += "IF EXISTS "
+	}
+	return result + accountNamesToQuery(x.Roles) + ";"
+}
+
+func (x *GrantStatement) statement() {}
+func (x *GrantStatement) ToQuery() string {
+	result := "GRANT " + privilegesToQuery(x.Privileges) + " ON " + x.Level.ToQuery() + " TO " + accountNamesToQuery(x.Users)
+	if x.WithGrantOption {
+		result += " WITH GRANT OPTION"
+	}
+	return result + ";"
+}
+
+func (x *GrantRoleStatement) statement() {}
+func (x *GrantRoleStatement) ToQuery() string {
+	result := "GRANT " + accountNamesToQuery(x.Roles) + " TO " + accountNamesToQuery(x.Users)
+	if x.WithAdminOption {
+		result += " WITH ADMIN OPTION"
+	}
+	return result + ";"
+}
+
+func (x *RevokeStatement) statement() {}
+func (x *RevokeStatement) ToQuery() string {
+	return "REVOKE " + privilegesToQuery(x.Privileges) + " ON " + x.Level.ToQuery() + " FROM " + accountNamesToQuery(x.Users) + ";"
+}
+
+func (x *RevokeAllStatement) statement() {}
+func (x *RevokeAllStatement) ToQuery() string {
+	return "REVOKE ALL PRIVILEGES, GRANT OPTION FROM " + accountNamesToQuery(x.Users) + ";"
+}
+
+func (x *RevokeRoleStatement) statement() {}
+func (x *RevokeRoleStatement) ToQuery() string {
+	return "REVOKE " + accountNamesToQuery(x.Roles) + " FROM " + accountNamesToQuery(x.Users) + ";"
+}
+
+func (x *SetDefaultRoleStatement) statement() {}
+func (x *SetDefaultRoleStatement) ToQuery() string {
+	roles := "NONE"
+	if x.All {
+		roles = "ALL"
+	} else if len(x.Roles) > 0 {
+		roles = accountNamesToQuery(x.Roles)
+	}
+	return "SET DEFAULT ROLE " + roles + " TO " + accountNamesToQuery(x.Users) + ";"
+}
+
+func (x *SetPasswordStatement) statement() {}
+func (x *SetPasswordStatement) ToQuery() string {
+	return x.toQuery(false)
+}
+func (x *SetPasswordStatement) toQuery(redact bool) string {
+	result := "SET PASSWORD"
+	if x.Account != nil {
+		result += " FOR " + x.Account.ToQuery()
+	}
+	return result + " = " + quotePassword(x.Password, redact) + ";"
+}
+
+func (x *UserSpecification) toQuery(redact bool) string {
+	if x.Auth == nil {
+		return x.Account.ToQuery()
+	}
+	return x.Account.ToQuery() + " " + x.Auth.toQuery(redact)
+}
+
+func (x *AuthOption) ToQuery() string {
+	return x.toQuery(false)
+}
+func (x *AuthOption) toQuery(redact bool) string {
+	result := "IDENTIFIED"
+	if x.Plugin != "" {
+		result += " WITH " + quoteName(x.Plugin, quoteIdentifier)
+	}
+	switch {
+	case x.RandomPassword:
+		result += " BY RANDOM PASSWORD"
+	case x.AuthString != "":
+		result += " AS " + quotePassword(x.AuthString, redact)
+	case x.Password != "" || x.Plugin == "":
+		result += " BY " + quotePassword(x.Password, redact)
+	}
+	return result
+}
+
+func (x *Privilege) ToQuery() string {
+	if len(x.Columns) == 0 {
+		return x.Type
+	}
+	var columns []string
+	for _, column := range x.Columns {
+		columns = append(columns, column.ToQuery())
+	}
+	return x.Type + " (" + strings.Join(columns, ", ") + ")"
+}
+
+func (x *PrivilegeLevel) ToQuery() string {
+	result := ""
+	if x.ObjectType != "" {
+		result += x.ObjectType + " "
+	}
+	if x.Database != "" {
+		result += quotePrivilegeLevelName(x.Database) + "."
+	}
+	return result + quotePrivilegeLevelName(x.Table)
+}
+
+func quotePrivilegeLevelName(name string) string {
+	if name == "*" {
+		return name
+	}
+	return quoteIdentifier(name)
+}
+
+func quotePassword(password string, redact bool) string {
+	if redact {
+		return quoteString(RedactedPassword)
+	}
+	return quoteString(password)
+}
+
+func userSpecificationsToQuery(users []UserSpecification, redact bool) string {
+	var result []string
+	for _, user := range users {
+		result = append(result, user.toQuery(redact))
+	}
+	return strings.Join(result, ", ")
+}
+
+func accountNamesToQuery(accounts []AccountNameIdentifier) string {
+	var result []string
+	for _, account := range accounts {
+		result = append(result, account.ToQuery())
+	}
+	return strings.Join(result, ", ")
+}
+
+func privilegesToQuery(privileges []Privilege) string {
+	var result []string
+	for _, privilege := range privileges {
+		result = append(result, privilege.ToQuery())
+	}
+	return strings.Join(result, ", ")
+}
+
+type (
+	// CreateTablespaceStatement is CREATE TABLESPACE. LogfileGroup is set for
+	// NDB tablespaces.
+	CreateTablespaceStatement struct {
+		Span
+		VersionGates
+		Undo         bool
+		Name         string
+		DataFile     string
+		LogfileGroup string
+		Options      []TableOption
+	}
+	// AlterTablespaceStatement is ALTER TABLESPACE. DataFileOperation is
+	// "ADD", "DROP" or empty, and Set is "ACTIVE", "INACTIVE" or empty.
+	AlterTablespaceStatement struct {
+		Span
+		VersionGates
+		Undo              bool
+		Name              string
+		DataFileOperation string
+		DataFile          string
+		RenameTo          string
+		Set               string
+		Options           []TableOption
+	}
+	DropTablespaceStatement struct {
+		Span
+		VersionGates
+		Undo    bool
+		Name    string
+		Options []TableOption
+	}
+	CreateLogfileGroupStatement struct {
+		Span
+		VersionGates
+		Name     string
+		UndoFile string
+		Options  []TableOption
+	}
+	AlterLogfileGroupStatement struct {
+		Span
+		VersionGates
+		Name     string
+		UndoFile string
+		Options  []TableOption
+	}
+	DropLogfileGroupStatement struct {
+		Span
+		VersionGates
+		Name    string
+		Options []TableOption
+	}
+	CreateServerStatement struct {
+		Span
+		VersionGates
+		Name    string
+		Wrapper string
+		Options []ServerOption
+	}
+	AlterServerStatement struct {
+		Span
+		VersionGates
+		Name    string
+		Options []ServerOption
+	}
+	DropServerStatement struct {
+		Span
+		VersionGates
+		IfExists bool
+		Name     string
+	}
+
+	// ServerOption is an option of CREATE SERVER such as HOST 'localhost'.
+	ServerOption struct {
+		Span
+		Key   string
+		Value string
+	}
+)
+
+func (x *CreateTablespaceStatement) statement() {}
+func (x *CreateTablespaceStatement) ToQuery() string {
+	result := "CREATE " + undoToQuery(x.Undo) + "TABLESPACE " + quoteIdentifier(x.Name)
+	if x.DataFile != "" {
+		result += " ADD DATAFILE " + quoteString(x.DataFile)
+	}
+	if x.LogfileGroup != "" {
+		result += " USE LOGFILE GROUP " + quoteIdentifier(x.LogfileGroup)
+	}
+	return result + tableOptionsToQuery(x.Options) + ";"
+}
+
+func (x *AlterTablespaceStatement) statement() {}
+func (x *AlterTablespaceStatement) ToQuery() string {
+	result := "ALTER " + undoToQuery(x.Undo) + "TABLESPACE " + quoteIdentifier(x.Name)
+	if x.DataFileOperation != "" {
+		result += " " + x.DataFileOperation + " DATAFILE " + quoteString(x.DataFile)
+	}
+	if x.RenameTo != "" {
+		result += " RENAME TO " + quoteIdentifier(x.RenameTo)
+	}
+	if x.Set != "" {
+		result += " SET " + x.Set
+	}
+	return result + tableOptionsToQuery(x.Options) + ";"
+}
+
+func (x *DropTablespaceStatement) statement() {}
+func (x *DropTablespaceStatement) ToQuery() string {
+	return "DROP " + undoToQuery(x.Undo) + "TABLESPACE " + quoteIdentifier(x.Name) + tableOptionsToQuery(x.Options) + ";"
+}
+
+func (x *CreateLogfileGroupStatement) statement() {}
+func (x *CreateLogfileGroupStatement) ToQuery() string {
+	return "CREATE LOGFILE GROUP " + quoteIdentifier(x.Name) + " ADD UNDOFILE " + quoteString(x.UndoFile) + tableOptionsToQuery(x.Options) + ";"
+}
+
+func (x *AlterLogfileGroupStatement) statement() {}
+func (x *AlterLogfileGroupStatement) ToQuery() string {
+	return "ALTER LOGFILE GROUP " + quoteIdentifier(x.Name) + " ADD UNDOFILE " + quoteString(x.UndoFile) + tableOptionsToQuery(x.Options) + ";"
+}
+
+func (x *DropLogfileGroupStatement) statement() {}
+func (x *DropLogfileGroupStatement) ToQuery() string {
+	return "DROP LOGFILE GROUP " + quoteIdentifier(x.Name) + tableOptionsToQuery(x.Options) + ";"
+}
+
+func (x *CreateServerStatement) statement() {}
+func (x *CreateServerStatement) ToQuery() string {
+	return x.toQuery(false)
+}
+func (x *CreateServerStatement) toQuery(redact bool) string {
+	return "CREATE SERVER " + quoteIdentifier(x.Name) + " FOREIGN DATA WRAPPER " + quoteIdentifier(x.Wrapper) + " OPTIONS (" + serverOptionsToQuery(x.Options, redact) + ");"
+}
+
+func (x *AlterServerStatement) statement() {}
+func (x *AlterServerStatement) ToQuery() string {
+	return x.toQuery(false)
+}
+func (x *AlterServerStatement) toQuery(redact bool) string {
+	return "ALTER SERVER " + quoteIdentifier(x.Name) + " OPTIONS (" + serverOptionsToQuery(x.Options, redact) + ");"
+}
+
+func (x *DropServerStatement) statement() {}
+func (x *DropServerStatement) ToQuery() string {
+	result := "DROP SERVER "
+	if x.IfExists {
+		result += "IF EXISTS "
+	}
+	return result + quoteIdentifier(x.Name) + ";"
+}
+
+func (x *ServerOption) ToQuery() string {
+	return x.toQuery(false)
+}
+func (x *ServerOption) toQuery(redact bool) string {
+	switch x.Key {
+	case "PORT":
+		return x.Key + " " + x.Value
+	case "PASSWORD":
+		return x.Key + " " + quotePassword(x.Value, redact)
+	default:
+		return x.Key + " " + quoteString(x.Value)
+	}
+}
+
+func undoToQuery(undo bool) string {
+	if undo {
+		return "UNDO "
+	}
+	return ""
+}
+
+func tableOptionsToQuery(options []TableOption) string {
+	result := ""
+	for _, option := range options {
+		result += " " + option.ToQuery()
+	}
+	return result
+}
+
+func serverOptionsToQuery(options []ServerOption, redact bool) string {
+	var result []string
+	for _, option := range options {
+		result = append(result, option.toQuery(redact))
+	}
+	return strings.Join(result, ", ")
+}
+
+type (
+	// StartTransactionStatement is START TRANSACTION. Characteristics are
+	// "WITH CONSISTENT SNAPSHOT", "READ WRITE" or "READ ONLY".
+	StartTransactionStatement struct {
+		Span
+		VersionGates
+		Characteristics []string
+	}
+	BeginStatement struct {
+		Span
+		VersionGates
+	}
+	// CommitStatement is COMMIT. Chain is "CHAIN", "NO CHAIN" or empty and
+	// Release is "RELEASE", "NO RELEASE" or empty.
+	CommitStatement struct {
+		Span
+		VersionGates
+		Chain   string
+		Release string
+	}
+	RollbackStatement struct {
+		Span
+		VersionGates
+		Chain   string
+		Release string
+	}
+	RollbackToSavepointStatement struct {
+		Span
+		VersionGates
+		Name string
+	}
+	SavepointStatement struct {
+		Span
+		VersionGates
+		Name string
+	}
+	ReleaseSavepointStatement struct {
+		Span
+		VersionGates
+		Name string
+	}
+	LockTablesStatement struct {
+		Span
+		VersionGates
+		Locks []TableLock
+	}
+	UnlockTablesStatement struct {
+		Span
+		VersionGates
+	}
+	AnalyzeTableStatement struct {
+		Span
+		VersionGates
+		NoWriteToBinlog bool
+		TableNames      []TableNameIdentifier
+	}
+	OptimizeTableStatement struct {
+		Span
+		VersionGates
+		NoWriteToBinlog bool
+		TableNames      []TableNameIdentifier
+	}
+	// CheckTableStatement is CHECK TABLE. Options are "FOR UPGRADE", "QUICK",
+	// "FAST", "MEDIUM", "EXTENDED" or "CHANGED".
+	CheckTableStatement struct {
+		Span
+		VersionGates
+		TableNames []TableNameIdentifier
+		Options    []string
+	}
+	// FlushStatement is FLUSH except FLUSH TABLES. Options are such as
+	// "PRIVILEGES" or "BINARY LOGS".
+	FlushStatement struct {
+		Span
+		VersionGates
+		NoWriteToBinlog bool
+		Options         []string
+	}
+	// FlushTablesStatement is FLUSH TABLES. Lock is "WITH READ LOCK",
+	// "FOR EXPORT" or empty.
+	FlushTablesStatement struct {
+		Span
+		VersionGates
+		NoWriteToBinlog bool
+		TableNames      []TableNameIdentifier
+		Lock            string
+	}
+
+	// TableLock is a table of LOCK TABLES. Type is "READ", "READ LOCAL",
+	// "WRITE" or "LOW_PRIORITY WRITE".
+	TableLock struct {
+		Span
+		TableName TableNameIdentifier
+		Alias     string
+		Type      string
+	}
+)
+
+func (x *StartTransactionStatement) statement() {}
+func (x *StartTransactionStatement) ToQuery() string {
+	if len(x.Characteristics) == 0 {
+		return "START TRANSACTION;"
+	}
+	return "START TRANSACTION " + strings.Join(x.Characteristics, ", ") + ";"
+}
+
+func (x *BeginStatement) statement() {}
+func (x *BeginStatement) ToQuery() string {
+	return "BEGIN;"
+}
+
+func (x *CommitStatement) statement() {}
+func (x *CommitStatement) ToQuery() string {
+	return "COMMIT" + completionToQuery(x.Chain, x.Release) + ";"
+}
+
+func (x *RollbackStatement) statement() {}
+func (x *RollbackStatement) ToQuery() string {
+	return "ROLLBACK" + completionToQuery(x.Chain, x.Release) + ";"
+}
+
+func (x *RollbackToSavepointStatement) statement() {}
+func (x *RollbackToSavepointStatement) ToQuery() string {
+	return "ROLLBACK TO SAVEPOINT " + quoteIdentifier(x.Name) + ";"
+}
+
+func (x *SavepointStatement) statement() {}
+func (x *SavepointStatement) ToQuery() string {
+	return "SAVEPOINT " + quoteIdentifier(x.Name) + ";"
+}
+
+func (x *ReleaseSavepointStatement) statement() {}
+func (x *ReleaseSavepointStatement) ToQuery() string {
+	return "RELEASE SAVEPOINT " + quoteIdentifier(x.Name) + ";"
+}
+
+func (x *LockTablesStatement) statement() {}
+func (x *LockTablesStatement) ToQuery() string {
+	var locks []string
+	for _, lock := range x.Locks {
+		locks = append(locks, lock.ToQuery())
+	}
+	return "LOCK TABLES " + strings.Join(locks, ", ") + ";"
+}
+
+func (x *UnlockTablesStatement) statement() {}
+func (x *UnlockTablesStatement) ToQuery() string {
+	return "UNLOCK TABLES;"
+}
+
+func (x *AnalyzeTableStatement) statement() {}
+func (x *AnalyzeTableStatement) ToQuery() string {
+	return "ANALYZE " + noWriteToBinlogToQuery(x.NoWriteToBinlog) + "TABLE " + tableNamesToQuery(x.TableNames) + ";"
+}
+
+func (x *OptimizeTableStatement) statement() {}
+func (x *OptimizeTableStatement) ToQuery() string {
+	return "OPTIMIZE " + noWriteToBinlogToQuery(x.NoWriteToBinlog) + "TABLE " + tableNamesToQuery(x.TableNames) + ";"
+}
+
+func (x *CheckTableStatement) statement() {}
+func (x *CheckTableStatement) ToQuery() string {
+	result := "CHECK TABLE " + tableNamesToQuery(x.TableNames)
+	for _, option := range x.Options {
+		result += " " + option
+	}
+	return result + ";"
+}
+
+func (x *FlushStatement) statement() {}
+func (x *FlushStatement) ToQuery() string {
+	return "FLUSH " + noWriteToBinlogToQuery(x.NoWriteToBinlog) + strings.Join(x.Options, ", ") + ";"
+}
+
+func (x *FlushTablesStatement) statement() {}
+func (x *FlushTablesStatement) ToQuery() string {
+	result := "FLUSH " + noWriteToBinlogToQuery(x.NoWriteToBinlog) + "TABLES"
+	if len(x.TableNames) > 0 {
+		result += " " + tableNamesToQuery(x.TableNames)
+	}
+	if x.Lock != "" {
+		result += " " + x.Lock
+	}
+	return result + ";"
+}
+
+func (x *TableLock) ToQuery() string {
+	if x.Alias == "" {
+		return x.TableName.ToQuery() + " " + x.Type
+	}
+	return x.TableName.ToQuery() + " AS " + quoteIdentifier(x.Alias) + " " + x.Type
+}
+
+func completionToQuery(chain string, release string) string {
+	result := ""
+	if chain != "" {
+		result += " AND " + chain
+	}
+	if release != "" {
+		result += " " + release
+	}
+	return result
+}
+
+func noWriteToBinlogToQuery(noWriteToBinlog bool) string {
+	if noWriteToBinlog {
+		return "NO_WRITE_TO_BINLOG "
+	}
+	return ""
+}
+
+func tableNamesToQuery(tableNames []TableNameIdentifier) string {
+	var result []string
+	for _, table := range tableNames {
+		result = append(result, table.ToQuery())
+	}
+	return strings.Join(result, ", ")
+}
+
+type (
+	// SelectStatement is SELECT. Hints is the content of optimizer hints
+	// comment such as "/*+ MAX_EXECUTION_TIME(1000) */". Lock is
+	// "FOR UPDATE", "FOR SHARE", "LOCK IN SHARE MODE" or empty, and
+	// LockOption is "NOWAIT", "SKIP LOCKED" or empty.
+	SelectStatement struct {
+		Span
+		VersionGates
+		With       *WithClause
+		Hints      string
+		Distinct   bool
+		Fields     []SelectField
+		From       []TableReference
+		Where      Expression
+		GroupBy    []Expression
+		Having     Expression
+		Windows    []WindowDefinition
+		OrderBy    []OrderByItem
+		Limit      *Limit
+		Lock       string
+		LockOf     []TableNameIdentifier
+		LockOption string
+	}
+	// SetOperationStatement is UNION, INTERSECT or EXCEPT of two queries.
+	// OrderBy and Limit are applied to the result of the operation.
+	SetOperationStatement struct {
+		Span
+		VersionGates
+		With     *WithClause
+		Left     QueryExpression
+		Operator string
+		All      bool
+		Right    QueryExpression
+		OrderBy  []OrderByItem
+		Limit    *Limit
+	}
+	// ParenQueryExpression is a parenthesized query used as an operand of set
+	// operations.
+	ParenQueryExpression struct {
+		Span
+		VersionGates
+		Query QueryExpression
+	}
+	// InsertStatement is INSERT or REPLACE. One of Values, Assignments and
+	// Select is set. Priority is "LOW_PRIORITY", "DELAYED", "HIGH_PRIORITY" or
+	// empty.
+	InsertStatement struct {
+		Span
+		VersionGates
+		Replace              bool
+		Hints                string
+		Priority             string
+		Ignore               bool
+		TableName            TableNameIdentifier
+		Columns              []ColumnNameIdentifier
+		Values               [][]Expression
+		Assignments          []Assignment
+		Select               QueryExpression
+		OnDuplicateKeyUpdate []Assignment
+	}
+	UpdateStatement struct {
+		Span
+		VersionGates
+		Hints       string
+		LowPriority bool
+		Ignore      bool
+		Tables      []TableReference
+		Assignments []Assignment
+		Where       Expression
+		OrderBy     []OrderByItem
+		Limit       *Limit
+	}
+	// DeleteStatement is DELETE. Tables is set for multiple-table syntax
+	// "DELETE FROM t1 USING t1 JOIN t2".
+	DeleteStatement struct {
+		Span
+		VersionGates
+		Hints       string
+		LowPriority bool
+		Quick       bool
+		Ignore      bool
+		Tables      []TableNameIdentifier
+		From        []TableReference
+		Where       Expression
+		OrderBy     []OrderByItem
+		Limit       *Limit
+	}
+	// PrepareStatement is PREPARE. Text is StringExpression or UserVariableExpression.
+	PrepareStatement struct {
+		Span
+		VersionGates
+		Name string
+		Text Expression
+	}
+	ExecuteStatement struct {
+		Span
+		VersionGates
+		Name  string
+		Using []Expression
+	}
+	DeallocatePrepareStatement struct {
+		Span
+		VersionGates
+		Name string
+	}
+
+	SelectField struct {
+		Span
+		Expression Expression
+		Alias      string
+	}
+	OrderByItem struct {
+		Span
+		Expression Expression
+		Desc       bool
+	}
+	// Limit is LIMIT clause. Offset is nil if it is omitted.
+	Limit struct {
+		Span
+		Count  Expression
+		Offset Expression
+	}
+	Assignment struct {
+		Span
+		Column *ColumnExpression
+		Value  Expression
+	}
+
+	TableReferenceTable struct {
+		Span
+		TableName TableNameIdentifier
+		Alias     string
+	}
+	TableReferenceSubquery struct {
+		Span
+		Lateral bool
+		Select  QueryExpression
+		Alias   string
+	}
+	TableReferenceJSONTable struct {
+		Span
+		Expression Expression
+		Path       string
+		Columns    []JSONTableColumn
+		Alias      string
+	}
+	// TableReferenceJoin is a joined table. Type is such as "JOIN", "LEFT JOIN"
+	// or "NATURAL JOIN".
+	TableReferenceJoin struct {
+		Span
+		Left  TableReference
+		Type  string
+		Right TableReference
+		On    Expression
+		Using []ColumnNameIdentifier
+	}
+	TableReferenceParen struct {
+		Span
+		References []TableReference
+	}
+
+	WithClause struct {
+		Span
+		Recursive              bool
+		CommonTableExpressions []CommonTableExpression
+	}
+	CommonTableExpression struct {
+		Span
+		Name    string
+		Columns []ColumnNameIdentifier
+		Query   QueryExpression
+	}
+	WindowDefinition struct {
+		Span
+		Name   string
+		Window *WindowSpecification
+	}
+	// WindowSpecification is a window of OVER or WINDOW clause. Name is the
+	// name of the referenced window. "OVER w" has Name only.
+	WindowSpecification struct {
+		Span
+		Name        string
+		PartitionBy []Expression
+		OrderBy     []OrderByItem
+		Frame       *WindowFrame
+	}
+	// WindowFrame is a frame clause of window. Unit is "ROWS" or "RANGE". End
+	// is nil if BETWEEN is omitted.
+	WindowFrame struct {
+		Span
+		Unit  string
+		Start WindowFrameBound
+		End   *WindowFrameBound
+	}
+	// WindowFrameBound is a frame boundary. Type is "UNBOUNDED PRECEDING",
+	// "UNBOUNDED FOLLOWING", "CURRENT ROW", "PRECEDING" or "FOLLOWING".
+	// Expression is set for "PRECEDING" and "FOLLOWING".
+	WindowFrameBound struct {
+		Span
+		Type       string
+		Expression Expression
+	}
+	// JSONTableColumn is a column of JSON_TABLE. Nested is set for NESTED PATH
+	// columns. OnEmpty and OnError are "NULL", "ERROR", "DEFAULT '...'" or empty.
+	JSONTableColumn struct {
+		Span
+		Name       string
+		Ordinality bool
+		DataType   DataTypeDefinition
+		Exists     bool
+		Path       string
+		OnEmpty    string
+		OnError    string
+		Nested     []JSONTableColumn
+	}
+)
+
+func (x *SelectStatement) statement() {}
+func (x *SelectStatement) ToQuery() string {
+	return x.toQuery() + ";"
+}
+func (x *SelectStatement) toQuery() string {
+	result := withToQuery(x.With) + "SELECT " + hintsToQuery(x.Hints)
+	if x.Distinct {
+		result += "DISTINCT "
+	}
+	var fields []string
+	for _, field := range x.Fields {
+		fields = append(fields, field.ToQuery())
+	}
+	result += strings.Join(fields, ", ")
+	if len(x.From) > 0 {
+		result += " FROM " + tableReferencesToQuery(x.From)
+	}
+	if x.Where != nil {
+		result += " WHERE " + x.Where.ToQuery()
+	}
+	if len(x.GroupBy) > 0 {
+		result += " GROUP BY " + expressionsToQuery(x.GroupBy)
+	}
+	if x.Having != nil {
+		result += " HAVING " + x.Having.ToQuery()
+	}
+	if len(x.Windows) > 0 {
+		var windows []string
+		for _, window := range x.Windows {
+			windows = append(windows, window.ToQuery())
+		}
+		result += " WINDOW " + strings.Join(windows, ", ")
+	}
+	result += orderByToQuery(x.OrderBy) + limitToQuery(x.Limit)
+	if x.Lock != "" {
+		result += " " + x.Lock
+	}
+	if len(x.LockOf) > 0 {
+		result += " OF " + tableNamesToQuery(x.LockOf)
+	}
+	if x.LockOption != "" {
+		result += " " + x.LockOption
+	}
+	return result
+}
+
+func (x *SetOperationStatement) statement() {}
+func (x *SetOperationStatement) ToQuery() string {
+	return x.toQuery() + ";"
+}
+func (x *SetOperationStatement) toQuery() string {
+	result := withToQuery(x.With) + x.Left.toQuery() + " " + x.Operator
+	if x.All {
+		result += " ALL"
+	}
+	return result + " " + x.Right.toQuery() + orderByToQuery(x.OrderBy) + limitToQuery(x.Limit)
+}
+
+func (x *ParenQueryExpression) statement() {}
+func (x *ParenQueryExpression) ToQuery() string {
+	return x.toQuery() + ";"
+}
+func (x *ParenQueryExpression) toQuery() string {
+	return "(" + x.Query.toQuery() + ")"
+}
+
+func (x *InsertStatement) statement() {}
+func (x *InsertStatement) ToQuery() string {
+	result := "INSERT "
+	if x.Replace {
+		result = "REPLACE "
+	}
+	result += hintsToQuery(x.Hints)
+	if x.Priority != "" {
+		result += x.Priority + " "
+	}
+	if x.Ignore {
+		result += "IGNORE "
+	}
+	result += "INTO " + x.TableName.ToQuery()
+	if x.Columns != nil {
+		var columns []string
+		for _, column := range x.Columns {
+			columns = append(columns, column.ToQuery())
+		}
+		result += " (" + strings.Join(columns, ", ") + ")"
+	}
+	switch {
+	case x.Select != nil:
+		result += " " + x.Select.toQuery()
+	case x.Assignments != nil:
+		result += " SET " + assignmentsToQuery(x.Assignments)
+	default:
+		var rows []string
+		for _, row := range x.Values {
+			rows = append(rows, "("+expressionsToQuery(row)+")")
+		}
+		result += " VALUES " + strings.Join(rows, ", ")
+	}
+	if len(x.OnDuplicateKeyUpdate) > 0 {
+		result += " ON DUPLICATE KEY UPDATE " + assignmentsToQuery(x.OnDuplicateKeyUpdate)
+	}
+	return result + ";"
+}
+
+func (x *UpdateStatement) statement() {}
+func (x *UpdateStatement) ToQuery() string {
+	result := "UPDATE " + hintsToQuery(x.Hints)
+	if x.LowPriority {
+		result += "LOW_PRIORITY "
+	}
+	if x.Ignore {
+		result += "IGNORE "
+	}
+	result += tableReferencesToQuery(x.Tables) + " SET " + assignmentsToQuery(x.Assignments)
+	if x.Where != nil {
+		result += " WHERE " + x.Where.ToQuery()
+	}
+	return result + orderByToQuery(x.OrderBy) + limitToQuery(x.Limit) + ";"
+}
+
+func (x *DeleteStatement) statement() {}
+func (x *DeleteStatement) ToQuery() string {
+	result := "DELETE " + hintsToQuery(x.Hints)
+	if x.LowPriority {
+		result += "LOW_PRIORITY "
+	}
+	if x.Quick {
+		result += "QUICK "
+	}
+	if x.Ignore {
+		result += "IGNORE "
+	}
+	if len(x.Tables) > 0 {
+		result += "FROM " + tableNamesToQuery(x.Tables) + " USING " + tableReferencesToQuery(x.From)
+	} else {
+		result += "FROM " + tableReferencesToQuery(x.From)
+	}
+	if x.Where != nil {
+		result += " WHERE " + x.Where.ToQuery()
+	}
+	return result + orderByToQuery(x.OrderBy) + limitToQuery(x.Limit) + ";"
+}
+
+func (x *PrepareStatement) statement() {}
+func (x *PrepareStatement) ToQuery() string {
+	return "PREPARE " + quoteIdentifier(x.Name) + " FROM " + x.Text.ToQuery() + ";"
+}
+
+func (x *ExecuteStatement) statement() {}
+func (x *ExecuteStatement) ToQuery() string {
+	if len(x.Using) == 0 {
+		return "EXECUTE " + quoteIdentifier(x.Name) + ";"
+	}
+	return "EXECUTE " + quoteIdentifier(x.Name) + " USING " + expressionsToQuery(x.Using) + ";"
+}
+
+func (x *DeallocatePrepareStatement) statement() {}
+func (x *DeallocatePrepareStatement) ToQuery() string {
+	return "DEALLOCATE PREPARE " + quoteIdentifier(x.Name) + ";"
+}
+
+func (x *SelectField) ToQuery() string {
+	if x.Alias == "" {
+		return x.Expression.ToQuery()
+	}
+	return x.Expression.ToQuery() + " AS " + quoteIdentifier(x.Alias)
+}
+
+func (x *OrderByItem) ToQuery() string {
+	if x.Desc {
+		return x.Expression.ToQuery() + " DESC"
+	}
+	return x.Expression.ToQuery()
+}
+
+func (x *Limit) ToQuery() string {
+	if x.Offset == nil {
+		return "LIMIT " + x.Count.ToQuery()
+	}
+	return "LIMIT " + x.Count.ToQuery() + " OFFSET " + x.Offset.ToQuery()
+}
+
+func (x *Assignment) ToQuery() string {
+	return x.Column.ToQuery() + " = " + x.Value.ToQuery()
+}
+
+func (x *TableReferenceTable) table_reference() {}
+func (x *TableReferenceTable) ToQuery() string {
+	if x.Alias == "" {
+		return x.TableName.ToQuery()
+	}
+	return x.TableName.ToQuery() + " AS " + quoteIdentifier(x.Alias)
+}
+func (x *TableReferenceSubquery) table_reference() {}
+func (x *TableReferenceSubquery) ToQuery() string {
+	if x.Lateral {
+		return "LATERAL (" + x.Select.toQuery() + ") AS " + quoteIdentifier(x.Alias)
+	}
+	return "(" + x.Select.toQuery() + ") AS " + quoteIdentifier(x.Alias)
+}
+func (x *TableReferenceJSONTable) table_reference() {}
+func (x *TableReferenceJSONTable) ToQuery() string {
+	return "JSON_TABLE(" + x.Expression.ToQuery() + ", " + quoteString(x.Path) + " COLUMNS (" + jsonTableColumnsToQuery(x.Columns) + ")) AS " + quoteIdentifier(x.Alias)
+}
+func (x *TableReferenceJoin) table_reference() {}
+func (x *TableReferenceJoin) ToQuery() string {
+	result := x.Left.ToQuery() + " " + x.Type + " " + x.Right.ToQuery()
+	if x.On != nil {
+		result += " ON " + x.On.ToQuery()
+	}
+	if len(x.Using) > 0 {
+		var columns []string
+		for _, column := range x.Using {
+			columns = append(columns, column.ToQuery())
+		}
+		result += " USING (" + strings.Join(columns, ", ") + ")"
+	}
+	return result
+}
+func (x *TableReferenceParen) table_reference() {}
+func (x *TableReferenceParen) ToQuery() string {
+	return "(" + tableReferencesToQuery(x.References) + ")"
+}
+
+func (x *WithClause) ToQuery() string {
+	result := "WITH "
+	if x.Recursive {
+		result += "RECURSIVE "
+	}
+	var ctes []string
+	for _, cte := range x.CommonTableExpressions {
+		ctes = append(ctes, cte.ToQuery())
+	}
+	return result + strings.Join(ctes, ", ")
+}
+
+func (x *CommonTableExpression) ToQuery() string {
+	result := quoteIdentifier(x.Name)
+	if len(x.Columns) > 0 {
+		var columns []string
+		for _, column := range x.Columns {
+			columns = append(columns, column.ToQuery())
+		}
+		result += " (" + strings.Join(columns, ", ") + ")"
+	}
+	return result + " AS (" + x.Query.toQuery() + ")"
+}
+
+func (x *WindowDefinition) ToQuery() string {
+	return quoteIdentifier(x.Name) + " AS " + x.Window.ToQuery()
+}
+
+func (x *WindowSpecification) ToQuery() string {
+	if x.PartitionBy == nil && x.OrderBy == nil && x.Frame == nil && x.Name != "" {
+		return quoteIdentifier(x.Name)
+	}
+	var result []string
+	if x.Name != "" {
+		result = append(result, quoteIdentifier(x.Name))
+	}
+	if len(x.PartitionBy) > 0 {
+		result = append(result, "PARTITION BY "+expressionsToQuery(x.PartitionBy))
+	}
+	if len(x.OrderBy) > 0 {
+		result = append(result, strings.TrimPrefix(orderByToQuery(x.OrderBy), " "))
+	}
+	if x.Frame != nil {
+		result = append(result, x.Frame.ToQuery())
+	}
+	return "(" + strings.Join(result, " ") + ")"
+}
+
+func (x *WindowFrame) ToQuery() string {
+	if x.End == nil {
+		return x.Unit + " " + x.Start.ToQuery()
+	}
+	return x.Unit + " BETWEEN " + x.Start.ToQuery() + " AND " + x.End.ToQuery()
+}
+
+func (x *WindowFrameBound) ToQuery() string {
+	if x.Expression == nil {
+		return x.Type
+	}
+	return x.Expression.ToQuery() + " " + x.Type
+}
+
+func (x *JSONTableColumn) ToQuery() string {
+	if x.Nested != nil {
+		return "NESTED PATH " + quoteString(x.Path) + " COLUMNS (" + jsonTableColumnsToQuery(x.Nested) + ")"
+	}
+	if x.Ordinality {
+		return quoteIdentifier(x.Name) + " FOR ORDINALITY"
+	}
+	if x.Exists {
+		return quoteIdentifier(x.Name) + " " + x.DataType.ToQuery() + " EXISTS PATH " + quoteString(x.Path)
+	}
+	result := quoteIdentifier(x.Name) + " " + x.DataType.ToQuery() + " PATH " + quoteString(x.Path)
+	if x.OnEmpty != "" {
+		result += " " + x.OnEmpty + " ON EMPTY"
+	}
+	if x.OnError != "" {
+		result += " " + x.OnError + " ON ERROR"
+	}
+	return result
+}
+
+func jsonTableColumnsToQuery(columns []JSONTableColumn) string {
+	var result []string
+	for _, column := range columns {
+		result = append(result, column.ToQuery())
+	}
+	return strings.Join(result, ", ")
+}
+
+func withToQuery(with *WithClause) string {
+	if with == nil {
+		return ""
+	}
+	return with.ToQuery() + " "
+}
+
+func hintsToQuery(hints string) string {
+	if hints == "" {
+		return ""
+	}
+	return "/*+" + hints + "*/ "
+}
+
+func tableReferencesToQuery(references []TableReference) string {
+	var result []string
+	for _, reference := range references {
+		result = append(result, reference.ToQuery())
+	}
+	return strings.Join(result, ", ")
+}
+
+func orderByToQuery(items []OrderByItem) string {
+	if len(items) == 0 {
+		return ""
+	}
+	var result []string
+	for _, item := range items {
+		result = append(result, item.ToQuery())
+	}
+	return " ORDER BY " + strings.Join(result, ", ")
+}
+
+func limitToQuery(limit *Limit) string {
+	if limit == nil {
+		return ""
+	}
+	return " " + limit.ToQuery()
+}
+
+func assignmentsToQuery(assignments []Assignment) string {
+	var result []string
+	for _, assignment := range assignments {
+		result = append(result, assignment.ToQuery())
+	}
+	return strings.Join(result, ", ")
+}
+
+type (
+	// ExplainStatement is EXPLAIN for SELECT, INSERT, REPLACE, UPDATE or
+	// DELETE. Format is "TRADITIONAL", "JSON", "TREE" or empty.
+	ExplainStatement struct {
+		Span
+		VersionGates
+		Analyze   bool
+		Format    string
+		Statement Statement
+	}
+	// DescribeStatement is DESCRIBE for a table. DESC and EXPLAIN with a
+	// table name are parsed as DescribeStatement as well. Column is a column
+	// name or wildcard pattern, or empty.
+	DescribeStatement struct {
+		Span
+		VersionGates
+		TableName TableNameIdentifier
+		Column    string
+	}
+	ShowCreateTableStatement struct {
+		Span
+		VersionGates
+		TableName TableNameIdentifier
+	}
+	ShowCreateDatabaseStatement struct {
+		Span
+		VersionGates
+		IfNotExists  bool
+		DatabaseName DatabaseNameIdentifier
+	}
+	ShowDatabasesStatement struct {
+		Span
+		VersionGates
+		Filter *ShowFilter
+	}
+	// ShowTablesStatement is SHOW TABLES. Database is empty if FROM is omitted.
+	ShowTablesStatement struct {
+		Span
+		VersionGates
+		Full     bool
+		Database DatabaseNameIdentifier
+		Filter   *ShowFilter
+	}
+	// ShowColumnsStatement is SHOW COLUMNS. "FROM t FROM db" is parsed into
+	// TableName as "db.t".
+	ShowColumnsStatement struct {
+		Span
+		VersionGates
+		Full      bool
+		TableName TableNameIdentifier
+		Filter    *ShowFilter
+	}
+	// ShowIndexStatement is SHOW INDEX. "FROM t FROM db" is parsed into
+	// TableName as "db.t".
+	ShowIndexStatement struct {
+		Span
+		VersionGates
+		TableName TableNameIdentifier
+		Where     Expression
+	}
+	// ShowVariablesStatement is SHOW VARIABLES. Scope is "GLOBAL", "SESSION" or empty.
+	ShowVariablesStatement struct {
+		Span
+		VersionGates
+		Scope  string
+		Filter *ShowFilter
+	}
+	// ShowStatusStatement is SHOW STATUS. Scope is "GLOBAL", "SESSION" or empty.
+	ShowStatusStatement struct {
+		Span
+		VersionGates
+		Scope  string
+		Filter *ShowFilter
+	}
+	ShowProcesslistStatement struct {
+		Span
+		VersionGates
+		Full bool
+	}
+	ShowWarningsStatement struct {
+		Span
+		VersionGates
+		Limit *Limit
+	}
+	ShowErrorsStatement struct {
+		Span
+		VersionGates
+		Limit *Limit
+	}
+	// ShowGrantsStatement is SHOW GRANTS. For is nil if FOR is omitted.
+	ShowGrantsStatement struct {
+		Span
+		VersionGates
+		For *AccountNameIdentifier
+	}
+
+	// ShowFilter is LIKE or WHERE clause of SHOW statements. Where is nil for LIKE.
+	ShowFilter struct {
+		Span
+		Like  string
+		Where Expression
+	}
+)
+
+func (x *ExplainStatement) statement() {}
+func (x *ExplainStatement) ToQuery() string {
+	result := "EXPLAIN "
+	if x.Analyze {
+		result += "ANALYZE "
+	}
+	if x.Format != "" {
+		result += "FORMAT=" + x.Format + " "
+	}
+	return result + x.Statement.ToQuery()
+}
+
+func (x *DescribeStatement) statement() {}
+func (x *DescribeStatement) ToQuery() string {
+	if x.Column == "" {
+		return "DESCRIBE " + x.TableName.ToQuery() + ";"
+	}
+	return "DESCRIBE " + x.TableName.ToQuery() + " " + quoteIdentifier(x.Column) + ";"
+}
+
+func (x *ShowCreateTableStatement) statement() {}
+func (x *ShowCreateTableStatement) ToQuery() string {
+	return "SHOW CREATE TABLE " + x.TableName.ToQuery() + ";"
+}
+
+func (x *ShowCreateDatabaseStatement) statement() {}
+func (x *ShowCreateDatabaseStatement) ToQuery() string {
+	if x.IfNotExists {
+		return "SHOW CREATE DATABASE IF NOT EXISTS " + x.DatabaseName.ToQuery() + ";"
+	}
+	return "SHOW CREATE DATABASE " + x.DatabaseName.ToQuery() + ";"
+}
+
+func (x *ShowDatabasesStatement) statement() {}
+func (x *ShowDatabasesStatement) ToQuery() string {
+	return "SHOW DATABASES" + x.Filter.toQuery() + ";"
+}
+
+func (x *ShowTablesStatement) statement() {}
+func (x *ShowTablesStatement) ToQuery() string {
+	result := "SHOW " + fullToQuery(x.Full) + "TABLES"
+	if x.Database.Name != "" {
+		result += " FROM " + x.Database.ToQuery()
+	}
+	return result + x.Filter.toQuery() + ";"
+}
+
+func (x *ShowColumnsStatement) statement() {}
+func (x *ShowColumnsStatement) ToQuery() string {
+	return "SHOW " + fullToQuery(x.Full) + "COLUMNS FROM " + x.TableName.ToQuery() + x.Filter.toQuery() + ";"
+}
+
+func (x *ShowIndexStatement) statement() {}
+func (x *ShowIndexStatement) ToQuery() string {
+	if x.Where == nil {
+		return "SHOW INDEX FROM " + x.TableName.ToQuery() + ";"
+	}
+	return "SHOW INDEX FROM " + x.TableName.ToQuery() + " WHERE " + x.Where.ToQuery() + ";"
+}
+
+func (x *ShowVariablesStatement) statement() {}
+func (x *ShowVariablesStatement) ToQuery() string {
+	return "SHOW " + scopeToQuery(x.Scope) + "VARIABLES" + x.Filter.toQuery() + ";"
+}
+
+func (x *ShowStatusStatement) statement() {}
+func (x *ShowStatusStatement) ToQuery() string {
+	return "SHOW " + scopeToQuery(x.Scope) + "STATUS" + x.Filter.toQuery() + ";"
+}
+
+func (x *ShowProcesslistStatement) statement() {}
+func (x *ShowProcesslistStatement) ToQuery() string {
+	return "SHOW " + fullToQuery(x.Full) + "PROCESSLIST;"
+}
+
+func (x *ShowWarningsStatement) statement() {}
+func (x *ShowWarningsStatement) ToQuery() string {
+	return "SHOW WARNINGS" + limitToQuery(x.Limit) + ";"
+}
+
+func (x *ShowErrorsStatement) statement() {}
+func (x *ShowErrorsStatement) ToQuery() string {
+	return "SHOW ERRORS" + limitToQuery(x.Limit) + ";"
+}
+
+func (x *ShowGrantsStatement) statement() {}
+func (x *ShowGrantsStatement) ToQuery() string {
+	if x.For == nil {
+		return "SHOW GRANTS;"
+	}
+	return "SHOW GRANTS FOR " + x.For.ToQuery() + ";"
+}
+
+// toQuery returns the filter with a leading space, or empty if x is nil.
+func (x *ShowFilter) toQuery() string {
+	if x == nil {
+		return ""
+	}
+	if x.Where != nil {
+		return " WHERE " + x.Where.ToQuery()
+	}
+	return " LIKE " + quoteString(x.Like)
+}
+
+func fullToQuery(full bool) string {
+	if full {
+		return "FULL "
+	}
+	return ""
+}
+
+func scopeToQuery(scope string) string {
+	if scope == "" {
+		return ""
+	}
+	return scope + " "
+}
+
+type (
+	SetAssignmentVariable struct {
+		Span
+		Variable Expression
+		Value    Expression
+	}
+	// SET NAMES. Empty Charset means DEFAULT.
+	SetAssignmentNames struct {
+		Span
+		Charset   string
+		Collation string
+	}
+	// SET CHARACTER SET. Empty Charset means DEFAULT.
+	SetAssignmentCharset struct {
+		Span
+		Charset string
+	}
+)
+
+func (x *SetAssignmentVariable) set_assignment() {}
+func (x *SetAssignmentVariable) ToQuery() string {
+	return x.Variable.ToQuery() + " = " + x.Value.ToQuery()
+}
+func (x *SetAssignmentNames) set_assignment() {}
+func (x *SetAssignmentNames) ToQuery() string {
+	if x.Charset == "" {
+		return "NAMES DEFAULT"
+	}
+	result := "NAMES " + quoteName(x.Charset, quoteString)
+	if x.Collation != "" {
+		result += " COLLATE " + quoteName(x.Collation, quoteString)
+	}
+	return result
+}
+func (x *SetAssignmentCharset) set_assignment() {}
+func (x *SetAssignmentCharset) ToQuery() string {
+	if x.Charset == "" {
+		return "CHARACTER SET DEFAULT"
+	}
+	return "CHARACTER SET " + quoteName(x.Charset, quoteString)
+}
+
+type (
+	// StringExpression is a string literal. Value is the decoded string,
+	// and Introducer is "N" or a character set introducer such as
+	// "_utf8mb4" if any.
+	StringExpression struct {
+		Span
+		Value      string
+		Introducer string
+	}
+	// HexStringExpression is a hexadecimal literal such as X'4D'. Value
+	// is the hexadecimal digits.
+	HexStringExpression struct {
+		Span
+		Value string
+	}
+	// BitStringExpression is a bit-value literal such as B'101'. Value
+	// is the binary digits.
+	BitStringExpression struct {
+		Span
+		Value string
+	}
+	// NumberExpression is a numeric literal. Value is the literal as
+	// written such as "-1.5e3" or "0x1F".
+	NumberExpression struct {
+		Span
+		Value string
+		Type  NumberType
+	}
+	NullExpression struct {
+		Span
+	}
+	// MAXVALUE in VALUES LESS THAN of partition definition
+	MaxValueExpression struct {
+		Span
+	}
+	DefaultExpression struct {
+		Span
+	}
+	ColumnExpression struct {
+		Span
+		TableName  TableNameIdentifier
+		ColumnName ColumnNameIdentifier
+	}
+	// @name
+	UserVariableExpression struct {
+		Span
+		Name string
+	}
+	// @@name. Scope is one of "", "GLOBAL", "SESSION", "PERSIST" and "PERSIST_ONLY".
+	SystemVariableExpression struct {
+		Span
+		Scope string
+		Name  string
+	}
+	// FunctionCallExpression is a function call. Distinct is set for
+	// aggregate functions such as COUNT(DISTINCT a).
+	FunctionCallExpression struct {
+		Span
+		Name      string
+		Arguments []Expression
+		Distinct  bool
+	}
+	UnaryExpression struct {
+		Span
+		Operator   string
+		Expression Expression
+	}
+	BinaryExpression struct {
+		Span
+		Operator string
+		Left     Expression
+		Right    Expression
+	}
+	ParenExpression struct {
+		Span
+		Expression Expression
+	}
+	// (expr, expr, ...)
+	RowExpression struct {
+		Span
+		Expressions []Expression
+	}
+	// IsExpression is "expr IS [NOT] value". Value is "NULL", "TRUE", "FALSE" or "UNKNOWN".
+	IsExpression struct {
+		Span
+		Expression Expression
+		Not        bool
+		Value      string
+	}
+	// InExpression is "expr [NOT] IN (...)". One of List and Subquery is set.
+	InExpression struct {
+		Span
+		Expression Expression
+		Not        bool
+		List       []Expression
+		Subquery   QueryExpression
+	}
+	BetweenExpression struct {
+		Span
+		Expression Expression
+		Not        bool
+		From       Expression
+		To         Expression
+	}
+	// LikeExpression is "expr [NOT] LIKE pattern [ESCAPE escape]". Escape is nil if it is omitted.
+	LikeExpression struct {
+		Span
+		Expression Expression
+		Not        bool
+		Pattern    Expression
+		Escape     Expression
+	}
+	SubqueryExpression struct {
+		Span
+		Select QueryExpression
+	}
+	ExistsExpression struct {
+		Span
+		Select QueryExpression
+	}
+	// WindowFunctionExpression is a function call with OVER clause.
+	WindowFunctionExpression struct {
+		Span
+		Function *FunctionCallExpression
+		Window   *WindowSpecification
+	}
+	BoolExpression struct {
+		Span
+		Value bool
+	}
+	// StarExpression is "*" of select fields or COUNT(*). TableName is set for "t.*".
+	StarExpression struct {
+		Span
+		TableName TableNameIdentifier
+	}
+	// ParamExpression is a placeholder "?". Ordinal is the position of the
+	// placeholder in the statement, starting at 1.
+	ParamExpression struct {
+		Span
+		Ordinal int
+	}
+)
+
+func (x *StringExpression) expression() {}
+func (x *StringExpression) ToQuery() string {
+	return x.Introducer + quoteString(x.Value)
+}
+func (x *HexStringExpression) expression() {}
+func (x *HexStringExpression) ToQuery() string {
+	return "X'" + x.Value + "'"
+}
+func (x *BitStringExpression) expression() {}
+func (x *BitStringExpression) ToQuery() string {
+	return "B'" + x.Value + "'"
+}
+func (x *NumberExpression) expression() {}
+func (x *NumberExpression) ToQuery() string {
+	return x.Value
+}
+func (x *NullExpression) expression() {}
+func (x *NullExpression) ToQuery() string {
+	return "NULL"
+}
+func (x *MaxValueExpression) expression() {}
+func (x *MaxValueExpression) ToQuery() string {
+	return "MAXVALUE"
+}
+func (x *DefaultExpression) expression() {}
+func (x *DefaultExpression) ToQuery() string {
+	return "DEFAULT"
+}
+func (x *ColumnExpression) expression() {}
+func (x *ColumnExpression) ToQuery() string {
+	if x.TableName.Name == "" {
+		return x.ColumnName.ToQuery()
+	}
+	return x.TableName.ToQuery() + "." + x.ColumnName.ToQuery()
+}
+func (x *UserVariableExpression) expression() {}
+func (x *UserVariableExpression) ToQuery() string {
+	return "@" + x.Name
+}
+func (x *SystemVariableExpression) expression() {}
+func (x *SystemVariableExpression) ToQuery() string {
+	if x.Scope == "" {
+		return "@@" + x.Name
+	}
+	return "@@" + x.Scope + "." + x.Name
+}
+func (x *FunctionCallExpression) expression() {}
+func (x *FunctionCallExpression) ToQuery() string {
+	if x.Distinct {
+		return x.Name + "(DISTINCT " + expressionsToQuery(x.Arguments) + ")"
+	}
+	return x.Name + "(" + expressionsToQuery(x.Arguments) + ")"
+}
+func (x *UnaryExpression) expression() {}
+func (x *UnaryExpression) ToQuery() string {
+	if x.Operator == "NOT" {
+		return "NOT " + x.Expression.ToQuery()
+	}
+	return x.Operator + x.Expression.ToQuery()
+}
+func (x *BinaryExpression) expression() {}
+func (x *BinaryExpression) ToQuery() string {
+	return x.Left.ToQuery() + " " + x.Operator + " " + x.Right.ToQuery()
+}
+func (x *ParenExpression) expression() {}
+func (x *ParenExpression) ToQuery() string {
+	return "(" + x.Expression.ToQuery() + ")"
+}
+func (x *RowExpression) expression() {}
+func (x *RowExpression) ToQuery() string {
+	return "(" + expressionsToQuery(x.Expressions) + ")"
+}
+
+func (x *IsExpression) expression() {}
+func (x *IsExpression) ToQuery() string {
+	if x.Not {
+		return x.Expression.ToQuery() + " IS NOT " + x.Value
+	}
+	return x.Expression.ToQuery() + " IS " + x.Value
+}
+func (x *InExpression) expression() {}
+func (x *InExpression) ToQuery() string {
+	list := ""
+	if x.Subquery != nil {
+		list = x.Subquery.toQuery()
+	} else {
+		list = expressionsToQuery(x.List)
+	}
+	return x.Expression.ToQuery() + notToQuery(x.Not) + " IN (" + list + ")"
+}
+func (x *BetweenExpression) expression() {}
+func (x *BetweenExpression) ToQuery() string {
+	return x.Expression.ToQuery() + notToQuery(x.Not) + " BETWEEN " + x.From.ToQuery() + " AND " + x.To.ToQuery()
+}
+func (x *LikeExpression) expression() {}
+func (x *LikeExpression) ToQuery() string {
+	result := x.Expression.ToQuery() + notToQuery(x.Not) + " LIKE " + x.Pattern.ToQuery()
+	if x.Escape != nil {
+		result += " ESCAPE " + x.Escape.ToQuery()
+	}
+	return result
+}
+func (x *SubqueryExpression) expression() {}
+func (x *SubqueryExpression) ToQuery() string {
+	return "(" + x.Select.toQuery() + ")"
+}
+func (x *ExistsExpression) expression() {}
+func (x *ExistsExpression) ToQuery() string {
+	return "EXISTS (" + x.Select.toQuery() + ")"
+}
+func (x *WindowFunctionExpression) expression() {}
+func (x *WindowFunctionExpression) ToQuery() string {
+	return x.Function.ToQuery() + " OVER " + x.Window.ToQuery()
+}
+func (x *BoolExpression) expression() {}
+func (x *BoolExpression) ToQuery() string {
+	if x.Value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+func (x *StarExpression) expression() {}
+func (x *StarExpression) ToQuery() string {
+	if x.TableName.Name == "" {
+		return "*"
+	}
+	return x.TableName.ToQuery() + ".*"
+}
+func (x *ParamExpression) expression() {}
+func (x *ParamExpression) ToQuery() string {
+	return "?"
+}
+
+func notToQuery(not bool) string {
+	if not {
+		return " NOT"
+	}
+	return ""
+}
+
+func expressionsToQuery(expressions []Expression) string {
+	var queries []string
+	for _, expression := range expressions {
+		queries = append(queries, expression.ToQuery())
+	}
+	return strings.Join(queries, ", ")
+}
+
+type (
+	// PartitionOptions is the PARTITION BY clause. VersionGate is the
+	// executable comment which encloses the clause. (e.g. /*!50100 PARTITION BY ... */)
+	PartitionOptions struct {
+		Span
+		VersionGate
+		PartitionBy    PartitionMethod
+		Partitions     uint
+		SubpartitionBy *PartitionMethod
+		Subpartitions  uint
+		Definitions    []PartitionDefinition
+	}
+	// Expression is used by HASH, RANGE and LIST. Columns is used by KEY,
+	// RANGE COLUMNS and LIST COLUMNS.
+	PartitionMethod struct {
+		Span
+		Type       PartitionType
+		Linear     bool
+		Algorithm  uint
+		Expression Expression
+		Columns    []ColumnNameIdentifier
+	}
+	PartitionDefinition struct {
+		Span
+		Name           PartitionNameIdentifier
+		ValuesLessThan []Expression
+		ValuesIn       []Expression
+		Options        []TableOption
+		Subpartitions  []SubpartitionDefinition
+	}
+	SubpartitionDefinition struct {
+		Span
+		Name    PartitionNameIdentifier
+		Options []TableOption
+	}
+)
+
+func (x *PartitionOptions) ToQuery() string {
+	result := "PARTITION BY " + x.PartitionBy.ToQuery()
+	if x.Partitions != 0 {
+		result += fmt.Sprintf(" PARTITIONS %d", x.Partitions)
+	}
+	if x.SubpartitionBy != nil {
+		result += " SUBPARTITION BY " + x.SubpartitionBy.ToQuery()
+		if x.Subpartitions != 0 {
+			result += fmt.Sprintf(" SUBPARTITIONS %d", x.Subpartitions)
+		}
+	}
+	if len(x.Definitions) > 0 {
+		var defs []string
+		for _, def := range x.Definitions {
+			defs = append(defs, def.ToQuery())
+		}
+		result += "\n(" + strings.Join(defs, ",\n ") + ")"
+	}
+	return x.wrap(result)
+}
+
+func (x *PartitionMethod) ToQuery() string {
+	result := ""
+	if x.Linear {
+		result += "LINEAR "
+	}
+	result += x.Type.String()
+	switch x.Type {
+	case PARTITION_TYPE_KEY, PARTITION_TYPE_RANGE_COLUMNS, PARTITION_TYPE_LIST_COLUMNS:
+		if x.Algorithm != 0 {
+			result += fmt.Sprintf(" ALGORITHM=%d", x.Algorithm)
+		}
+		var columns []string
+		for _, column := range x.Columns {
+			columns = append(columns, column.ToQuery())
+		}
+		result += " (" + strings.Join(columns, ", ") + ")"
+	default:
+		result += " (" + x.Expression.ToQuery() + ")"
+	}
+	return result
+}
+
+func (x *PartitionDefinition) ToQuery() string {
+	result := "PARTITION " + x.Name.ToQuery()
+	if len(x.ValuesLessThan) > 0 {
+		result += " VALUES LESS THAN (" + expressionsToQuery(x.ValuesLessThan) + ")"
+	}
+	if len(x.ValuesIn) > 0 {
+		result += " VALUES IN (" + expressionsToQuery(x.ValuesIn) + ")"
+	}
+	for _, option := range x.Options {
+		result += " " + option.ToQuery()
+	}
+	if len(x.Subpartitions) > 0 {
+		var subpartitions []string
+		for _, subpartition := range x.Subpartitions {
+			subpartitions = append(subpartitions, subpartition.ToQuery())
+		}
+		result += " (" + strings.Join(subpartitions, ", ") + ")"
+	}
+	return result
+}
+
+func (x *SubpartitionDefinition) ToQuery() string {
+	result := "SUBPARTITION " + x.Name.ToQuery()
+	for _, option := range x.Options {
+		result += " " + option.ToQuery()
+	}
+	return result
+}
+
+func partitionDefinitionsToQuery(definitions []PartitionDefinition) string {
+	var defs []string
+	for _, def := range definitions {
+		defs = append(defs, def.ToQuery())
+	}
+	return strings.Join(defs, ", ")
+}
+
+func partitionNamesToQuery(names []PartitionNameIdentifier) string {
+	var queries []string
+	for _, name := range names {
+		queries = append(queries, name.ToQuery())
 	}
+	return strings.Join(queries, ", ")
 }
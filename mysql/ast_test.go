@@ -1,79 +1,403 @@
 package mysql
 
 import (
+	"reflect"
 	"testing"
 )
 
 func TestGenDropTableStatement(t *testing.T) {
 	testGenStatement(t, "DROP TABLE `hoge`;", &DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "hoge"}}})
+	testGenStatement(t, "DROP TABLE IF EXISTS `hoge`;", &DropTableStatement{IfExists: true, TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "hoge"}}})
 	testGenStatement(t, "DROP TABLE `fuga`, `hoge`;", &DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "fuga"}, TableNameIdentifier{Name: "hoge"}}})
 	testGenStatement(t, "DROP TABLE `TABLE`;", &DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "TABLE"}}})
 	testGenStatement(t, "DROP TABLE `hoge`.`fuga`;", &DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Database: "hoge", Name: "fuga"}}})
 }
 
+func TestGenQuotedIdentifier(t *testing.T) {
+	testGenStatement(t, "DROP TABLE `a``b`.`c```;", &DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Database: "a`b", Name: "c`"}}})
+	testGenStatement(t, "DROP USER `x``; DROP TABLE t; --`@`%`;", &DropUserStatement{Users: []AccountNameIdentifier{AccountNameIdentifier{User: "x`; DROP TABLE t; --", Host: "%"}}})
+	testGenStatement(t, "SELECT `t``1`.`a``b` AS `c``d` FROM `t``1`;", &SelectStatement{
+		Fields: []SelectField{SelectField{Expression: &ColumnExpression{TableName: TableNameIdentifier{Name: "t`1"}, ColumnName: ColumnNameIdentifier{Name: "a`b"}}, Alias: "c`d"}},
+		From:   []TableReference{&TableReferenceTable{TableName: TableNameIdentifier{Name: "t`1"}}},
+	})
+	testGenStatement(t, "SAVEPOINT `s``p`;", &SavepointStatement{Name: "s`p"})
+}
+
+func TestGenStringLiteral(t *testing.T) {
+	testGenStatement(t, "SELECT 'it''s', 'C:\\\\path', 'a\\0\\n\\r\\Z', N'x', _utf8mb4'y', X'4D', B'101';", &SelectStatement{Fields: []SelectField{
+		SelectField{Expression: &StringExpression{Value: "it's", Introducer: ""}},
+		SelectField{Expression: &StringExpression{Value: "C:\\path", Introducer: ""}},
+		SelectField{Expression: &StringExpression{Value: "a\x00\n\r\x1a", Introducer: ""}},
+		SelectField{Expression: &StringExpression{Value: "x", Introducer: "N"}},
+		SelectField{Expression: &StringExpression{Value: "y", Introducer: "_utf8mb4"}},
+		SelectField{Expression: &HexStringExpression{Value: "4D"}},
+		SelectField{Expression: &BitStringExpression{Value: "101"}},
+	}})
+	testGenStatement(t, "CREATE LOGFILE GROUP `lg` ADD UNDOFILE 'x''; DROP TABLE t; --';", &CreateLogfileGroupStatement{Name: "lg", UndoFile: "x'; DROP TABLE t; --"})
+}
+
 func TestGenDropDatabaseStatement(t *testing.T) {
 	testGenStatement(t, "DROP DATABASE `hoge`;", &DropDatabaseStatement{DatabaseName: DatabaseNameIdentifier{Name: "hoge"}})
+	testGenStatement(t, "DROP DATABASE IF EXISTS `hoge`;", &DropDatabaseStatement{IfExists: true, DatabaseName: DatabaseNameIdentifier{Name: "hoge"}})
 }
 
 func TestGenCreateDatabaseStatement(t *testing.T) {
 	testGenStatement(t, "CREATE DATABASE `hoge`;", &CreateDatabaseStatement{DatabaseName: DatabaseNameIdentifier{Name: "hoge"}})
+	testGenStatement(t, "CREATE DATABASE /*!32312 IF NOT EXISTS */ `hoge` /*!40100 DEFAULT CHARACTER SET=utf8mb4 */ ENCRYPTION 'N';", &CreateDatabaseStatement{
+		IfNotExists:     true,
+		IfNotExistsGate: VersionGate{Enclosed: true, Version: 32312},
+		DatabaseName:    DatabaseNameIdentifier{Name: "hoge"},
+		Options: []TableOption{
+			&TableOptionName{VersionGate: VersionGate{Enclosed: true, Version: 40100}, Key: "DEFAULT CHARACTER SET", Value: "utf8mb4"},
+			&TableOptionString{Key: "ENCRYPTION", Value: "N"},
+		},
+	})
+}
+
+func TestGenUseStatement(t *testing.T) {
+	testGenStatement(t, "USE `hoge`;", &UseStatement{DatabaseName: DatabaseNameIdentifier{Name: "hoge"}})
 }
 
 func TestGenAlterStatement(t *testing.T) {
-	testGenStatement(t, "ALTER TABLE `hoge` DROP `foo`;", &AlterTableStatement{TableNameIdentifier{Name: "hoge", Database: ""}, []AlterSpecification{
-		&AlterSpecificationDropColumn{ColumnNameIdentifier{"foo"}},
+	testGenStatement(t, "ALTER TABLE `hoge` DROP `foo`;", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge", Database: ""}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationDropColumn{ColumnName: ColumnNameIdentifier{Name: "foo"}},
 	}})
-	testGenStatement(t, "ALTER TABLE `hoge` DROP INDEX `foo`;", &AlterTableStatement{TableNameIdentifier{Name: "hoge", Database: ""}, []AlterSpecification{
-		&AlterSpecificationDropIndex{IndexNameIdentifier{"foo"}},
+	testGenStatement(t, "ALTER TABLE `hoge` DROP INDEX `foo`;", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge", Database: ""}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationDropIndex{Name: IndexNameIdentifier{Name: "foo"}},
 	}})
-	testGenStatement(t, "ALTER TABLE `hoge` ADD INDEX `foo` (`bar`, `baz`);", &AlterTableStatement{TableNameIdentifier{Name: "hoge", Database: ""}, []AlterSpecification{
-		&AlterSpecificationAddIndex{IndexNameIdentifier{"foo"}, []ColumnNameIdentifier{ColumnNameIdentifier{"bar"}, ColumnNameIdentifier{"baz"}}, false},
+	testGenStatement(t, "ALTER TABLE `hoge` DISABLE KEYS;", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge", Database: ""}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationKeys{Enable: false},
 	}})
-	testGenStatement(t, "ALTER TABLE `hoge` ADD INDEX (`bar`, `baz`);", &AlterTableStatement{TableNameIdentifier{Name: "hoge", Database: ""}, []AlterSpecification{
-		&AlterSpecificationAddIndex{IndexNameIdentifier{""}, []ColumnNameIdentifier{ColumnNameIdentifier{"bar"}, ColumnNameIdentifier{"baz"}}, false},
+	testGenStatement(t, "ALTER TABLE `hoge` ENGINE=InnoDB /*!50100 ROW_FORMAT=DYNAMIC */, DROP `foo`;", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge", Database: ""}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationTableOptions{TableOptions: []TableOption{&TableOptionName{Key: "ENGINE", Value: "InnoDB"}, &TableOptionName{VersionGate: VersionGate{Enclosed: true, Version: 50100}, Key: "ROW_FORMAT", Value: "DYNAMIC"}}},
+		&AlterSpecificationDropColumn{ColumnName: ColumnNameIdentifier{Name: "foo"}},
 	}})
-	testGenStatement(t, "ALTER TABLE `hoge` ADD UNIQUE INDEX (`bar`, `baz`);", &AlterTableStatement{TableNameIdentifier{Name: "hoge", Database: ""}, []AlterSpecification{
-		&AlterSpecificationAddIndex{IndexNameIdentifier{""}, []ColumnNameIdentifier{ColumnNameIdentifier{"bar"}, ColumnNameIdentifier{"baz"}}, true},
+	testGenStatement(t, "ALTER TABLE `hoge` ADD INDEX `foo` (`bar`, `baz`);", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge", Database: ""}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationAddIndex{Name: IndexNameIdentifier{Name: "foo"}, Columns: []ColumnNameIdentifier{ColumnNameIdentifier{Name: "bar"}, ColumnNameIdentifier{Name: "baz"}}, Unique: false},
 	}})
-	testGenStatement(t, "ALTER TABLE `hoge` ADD `foo` INT(10) UNSIGNED DEFAULT NULL;", &AlterTableStatement{TableNameIdentifier{Name: "hoge", Database: ""}, []AlterSpecification{
-		&AlterSpecificationAddColumn{ColumnNameIdentifier{"foo"}, ColumnDefinition{
-			&DataTypeDefinitionNumber{DATATYPE_INT, 10, true, false},
-			true,
-			false,
-			&DefaultDefinitionNull{},
+	testGenStatement(t, "ALTER TABLE `hoge` ADD INDEX (`bar`, `baz`);", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge", Database: ""}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationAddIndex{Name: IndexNameIdentifier{Name: ""}, Columns: []ColumnNameIdentifier{ColumnNameIdentifier{Name: "bar"}, ColumnNameIdentifier{Name: "baz"}}, Unique: false},
+	}})
+	testGenStatement(t, "ALTER TABLE `hoge` ADD UNIQUE INDEX (`bar`, `baz`);", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge", Database: ""}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationAddIndex{Name: IndexNameIdentifier{Name: ""}, Columns: []ColumnNameIdentifier{ColumnNameIdentifier{Name: "bar"}, ColumnNameIdentifier{Name: "baz"}}, Unique: true},
+	}})
+	testGenStatement(t, "ALTER TABLE `hoge` ADD `foo` INT(10) UNSIGNED DEFAULT NULL;", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge", Database: ""}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationAddColumn{ColumnName: ColumnNameIdentifier{Name: "foo"}, ColumnDefinition: ColumnDefinition{
+			DataTypeDefinition: &DataTypeDefinitionNumber{Type: DATATYPE_INT, Length: 10, Unsigned: true, Zerofill: false},
+			Nullable:           true,
+			AutoIncrement:      false,
+			Default:            &DefaultDefinitionNull{},
 		}},
 	}})
 }
 
 func TestGenCreateTableStatement(t *testing.T) {
-	testGenStatement(t, "CREATE TABLE `hoge` (\n\t`id` INT(10) UNSIGNED NOT NULL AUTO_INCREMENT ,\n\t`another_id` INT(10) UNSIGNED NOT NULL ,\n\tPRIMARY KEY ( `id` ),\n\tUNIQUE KEY `another_id` ( `another_id` ),\n\tINDEX `another_id2` ( `another_id` )\n) ENGINE=InnoDB COMMENT \"hoge\";", &CreateTableStatement{TableNameIdentifier{"hoge", ""}, []CreateDefinition{
-		&CreateDefinitionColumn{ColumnNameIdentifier{"id"}, ColumnDefinition{&DataTypeDefinitionNumber{DATATYPE_INT, 10, true, false}, false, true, &DefaultDefinitionEmpty{}}},
-		&CreateDefinitionColumn{ColumnNameIdentifier{"another_id"}, ColumnDefinition{&DataTypeDefinitionNumber{DATATYPE_INT, 10, true, false}, false, false, &DefaultDefinitionEmpty{}}},
-		&CreateDefinitionPrimaryIndex{[]ColumnNameIdentifier{ColumnNameIdentifier{"id"}}},
-		&CreateDefinitionUniqueIndex{IndexNameIdentifier{"another_id"}, []ColumnNameIdentifier{ColumnNameIdentifier{"another_id"}}},
-		&CreateDefinitionIndex{IndexNameIdentifier{"another_id2"}, []ColumnNameIdentifier{ColumnNameIdentifier{"another_id"}}},
-	}, []TableOption{TableOption{"ENGINE", "InnoDB"}, TableOption{"COMMENT", "hoge"}}})
+	testGenStatement(t, "CREATE TABLE `hoge` (\n\t`id` INT(10) UNSIGNED NOT NULL AUTO_INCREMENT ,\n\t`another_id` INT(10) UNSIGNED NOT NULL ,\n\tPRIMARY KEY ( `id` ),\n\tUNIQUE KEY `another_id` ( `another_id` ),\n\tINDEX `another_id2` ( `another_id` )\n) ENGINE=InnoDB COMMENT 'hoge';", &CreateTableStatement{TableName: TableNameIdentifier{Name: "hoge", Database: ""}, CreateDefinitions: []CreateDefinition{
+		&CreateDefinitionColumn{ColumnName: ColumnNameIdentifier{Name: "id"}, ColumnDefinition: ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionNumber{Type: DATATYPE_INT, Length: 10, Unsigned: true, Zerofill: false}, Nullable: false, AutoIncrement: true, Default: &DefaultDefinitionEmpty{}}},
+		&CreateDefinitionColumn{ColumnName: ColumnNameIdentifier{Name: "another_id"}, ColumnDefinition: ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionNumber{Type: DATATYPE_INT, Length: 10, Unsigned: true, Zerofill: false}, Nullable: false, AutoIncrement: false, Default: &DefaultDefinitionEmpty{}}},
+		&CreateDefinitionPrimaryIndex{Columns: []ColumnNameIdentifier{ColumnNameIdentifier{Name: "id"}}},
+		&CreateDefinitionUniqueIndex{Name: IndexNameIdentifier{Name: "another_id"}, Columns: []ColumnNameIdentifier{ColumnNameIdentifier{Name: "another_id"}}},
+		&CreateDefinitionIndex{Name: IndexNameIdentifier{Name: "another_id2"}, Columns: []ColumnNameIdentifier{ColumnNameIdentifier{Name: "another_id"}}},
+	}, TableOptions: []TableOption{&TableOptionName{Key: "ENGINE", Value: "InnoDB"}, &TableOptionString{Key: "COMMENT", Value: "hoge"}}, PartitionOptions: nil})
+}
+
+func TestGenTableOption(t *testing.T) {
+	testGenTableOption(t, "ROW_FORMAT=COMPRESSED", &TableOptionName{Key: "ROW_FORMAT", Value: "COMPRESSED"})
+	testGenTableOption(t, "AUTO_INCREMENT=10", &TableOptionNumber{Key: "AUTO_INCREMENT", Value: 10})
+	testGenTableOption(t, "CONNECTION 'mysql://user@host/db/t'", &TableOptionString{Key: "CONNECTION", Value: "mysql://user@host/db/t"})
+	testGenTableOption(t, "PACK_KEYS=DEFAULT", &TableOptionDefault{Key: "PACK_KEYS"})
+	testGenTableOption(t, "TABLESPACE `ts`", &TableOptionTablespace{Name: "ts"})
+	testGenTableOption(t, "TABLESPACE `ts` STORAGE MEMORY", &TableOptionTablespace{Name: "ts", Storage: "MEMORY"})
+	testGenTableOption(t, "UNION=(`t1`, `t2`)", &TableOptionUnion{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "t1"}, TableNameIdentifier{Name: "t2"}}})
+}
+
+func TestGenQuotedName(t *testing.T) {
+	testGenTableOption(t, "ENGINE=`x' y`", &TableOptionName{Key: "ENGINE", Value: "x' y"})
+	testGenTableOption(t, "ENGINE=`a``b`", &TableOptionName{Key: "ENGINE", Value: "a`b"})
+	testGenTableOption(t, "DEFAULT CHARACTER SET='utf8''; DROP TABLE t; --'", &TableOptionName{Key: "DEFAULT CHARACTER SET", Value: "utf8'; DROP TABLE t; --"})
+	testGenTableOption(t, "DEFAULT CHARACTER SET=DEFAULT", &TableOptionName{Key: "DEFAULT CHARACTER SET", Value: ""})
+	testGenTableOption(t, "SECONDARY_ENGINE=NULL", &TableOptionName{Key: "SECONDARY_ENGINE", Value: "NULL"})
+	testGenTableOption(t, "ROW_FORMAT=DEFAULT", &TableOptionName{Key: "ROW_FORMAT", Value: "DEFAULT"})
+	testGenTableOption(t, "ROW_FORMAT=`x y`", &TableOptionName{Key: "ROW_FORMAT", Value: "x y"})
+	testGenStatement(t, "SET NAMES 'a b' COLLATE 'select';", &SetStatement{Assignments: []SetAssignment{&SetAssignmentNames{Charset: "a b", Collation: "select"}}})
+	testGenStatement(t, "ALTER USER `u` IDENTIFIED WITH `a b`;", &AlterUserStatement{Users: []UserSpecification{UserSpecification{Account: AccountNameIdentifier{User: "u"}, Auth: &AuthOption{Plugin: "a b"}}}})
+}
+
+func TestGenRoundTrip(t *testing.T) {
+	for _, src := range []string{
+		"CREATE TABLE t (id INT) ENGINE='x'' y' DEFAULT CHARSET='a b' COLLATE 'c''d';",
+		"CREATE TABLE t (id INT) ENGINE=`a b` SECONDARY_ENGINE=`select`;",
+		"CREATE TABLE t (id INT) ENGINE=InnoDB SECONDARY_ENGINE=NULL ROW_FORMAT=DEFAULT;",
+		"CREATE USER u IDENTIFIED WITH `a b` BY 'x''y';",
+		"ALTER USER u IDENTIFIED WITH 'it''s';",
+		"SET NAMES 'utf8 mb4' COLLATE 'a''b';",
+		"SET CHARACTER SET 'a b';",
+		"CREATE TABLE t (id INT) /*!50100 ENGINE=InnoDB */;",
+		"CREATE TABLE t (id INT) ENGINE=InnoDB /*!50100 ROW_FORMAT=COMPACT, STATS_PERSISTENT=DEFAULT */ /*! TABLESPACE ts */;",
+		"CREATE TABLE t (id INT) DEFAULT CHARSET=utf8mb4 /*!50100 PARTITION BY HASH (id) PARTITIONS 4 */;",
+		"ALTER TABLE t ENGINE=InnoDB /*!50100 ROW_FORMAT=COMPACT */, AUTOEXTEND_SIZE=4M, ADD INDEX (id);",
+		"CREATE TABLE t (a INT, b INT GENERATED ALWAYS AS ((`a` + 1)) STORED NOT NULL, c VARCHAR(10) AS (concat(a, 'x')));",
+	} {
+		testRoundTrip(t, src)
+	}
+}
+
+// testRoundTrip checks that the query generated from the statement of src
+// is parsed to the same statement.
+func testRoundTrip(t *testing.T, src string) {
+	s := new(Scanner)
+	s.Init(src)
+	statements, err := Parse(s)
+	if err != nil || len(statements) != 1 {
+		t.Errorf("Parse failed about %q: %v", src, err)
+		return
+	}
+	query := statements[0].ToQuery()
+	s = new(Scanner)
+	s.Init(query)
+	regenerated, err := Parse(s)
+	if err != nil || len(regenerated) != 1 {
+		t.Errorf("Parse failed about %q generated from %q: %v", query, src, err)
+		return
+	}
+	clearSpans(reflect.ValueOf(statements))
+	clearSpans(reflect.ValueOf(regenerated))
+	if !reflect.DeepEqual(statements, regenerated) {
+		t.Errorf("Round trip failed about %q:\n\tGenerated\t: %q\n\tExpect\t: %+#v\n\tBut Got\t: %+#v", src, query, statements[0], regenerated[0])
+	}
+}
+
+func testGenTableOption(t *testing.T, expect string, option TableOption) {
+	if got := option.ToQuery(); got != expect {
+		t.Errorf("Expect %q, but got %q", expect, got)
+	}
+}
+
+func TestGenAccountStatement(t *testing.T) {
+	app := []AccountNameIdentifier{AccountNameIdentifier{User: "app", Host: "%"}}
+	testGenStatement(t, "CREATE USER IF NOT EXISTS `app`@`%` IDENTIFIED BY 'secret' DEFAULT ROLE `reader` ACCOUNT LOCK;", &CreateUserStatement{IfNotExists: true, Users: []UserSpecification{UserSpecification{Account: app[0], Auth: &AuthOption{Password: "secret"}}}, DefaultRoles: []AccountNameIdentifier{AccountNameIdentifier{User: "reader"}}, AccountLock: "LOCK"})
+	testGenStatement(t, "ALTER USER CURRENT_USER IDENTIFIED WITH auth_socket;", &AlterUserStatement{Users: []UserSpecification{UserSpecification{Account: AccountNameIdentifier{CurrentUser: true}, Auth: &AuthOption{Plugin: "auth_socket"}}}})
+	testGenStatement(t, "DROP ROLE IF EXISTS `reader`;", &DropRoleStatement{IfExists: true, Roles: []AccountNameIdentifier{AccountNameIdentifier{User: "reader"}}})
+	testGenStatement(t, "GRANT SELECT (`id`), INSERT ON FUNCTION `db`.* TO `app`@`%` WITH GRANT OPTION;", &GrantStatement{Privileges: []Privilege{Privilege{Type: "SELECT", Columns: []ColumnNameIdentifier{ColumnNameIdentifier{Name: "id"}}}, Privilege{Type: "INSERT"}}, Level: PrivilegeLevel{ObjectType: "FUNCTION", Database: "db", Table: "*"}, Users: app, WithGrantOption: true})
+	testGenStatement(t, "REVOKE `reader` FROM `app`@`%`;", &RevokeRoleStatement{Roles: []AccountNameIdentifier{AccountNameIdentifier{User: "reader"}}, Users: app})
+	testGenStatement(t, "SET DEFAULT ROLE NONE TO `app`@`%`;", &SetDefaultRoleStatement{Users: app})
+	testGenStatement(t, "SET PASSWORD FOR `app`@`%` = 'secret';", &SetPasswordStatement{Account: &app[0], Password: "secret"})
+}
+
+func TestGenTablespaceStatement(t *testing.T) {
+	testGenStatement(t, "CREATE TABLESPACE `ts1` ADD DATAFILE 'ts1.dat' USE LOGFILE GROUP `lg1` EXTENT_SIZE=1048576 ENGINE=ndbcluster;", &CreateTablespaceStatement{Name: "ts1", DataFile: "ts1.dat", LogfileGroup: "lg1", Options: []TableOption{&TableOptionNumber{Key: "EXTENT_SIZE", Value: 1048576}, &TableOptionName{Key: "ENGINE", Value: "ndbcluster"}}})
+	testGenStatement(t, "ALTER UNDO TABLESPACE `undo_003` SET ACTIVE;", &AlterTablespaceStatement{Undo: true, Name: "undo_003", Set: "ACTIVE"})
+	testGenStatement(t, "ALTER TABLESPACE `ts1` DROP DATAFILE 'ts2.dat' WAIT;", &AlterTablespaceStatement{Name: "ts1", DataFileOperation: "DROP", DataFile: "ts2.dat", Options: []TableOption{&TableOptionFlag{Key: "WAIT"}}})
+	testGenStatement(t, "DROP UNDO TABLESPACE `undo_003`;", &DropTablespaceStatement{Undo: true, Name: "undo_003"})
+	testGenStatement(t, "CREATE LOGFILE GROUP `lg1` ADD UNDOFILE 'undo.dat' INITIAL_SIZE=134217728 ENGINE=ndbcluster;", &CreateLogfileGroupStatement{Name: "lg1", UndoFile: "undo.dat", Options: []TableOption{&TableOptionNumber{Key: "INITIAL_SIZE", Value: 134217728}, &TableOptionName{Key: "ENGINE", Value: "ndbcluster"}}})
+	testGenStatement(t, "DROP LOGFILE GROUP `lg1` ENGINE=ndbcluster;", &DropLogfileGroupStatement{Name: "lg1", Options: []TableOption{&TableOptionName{Key: "ENGINE", Value: "ndbcluster"}}})
+	testGenStatement(t, "CREATE SERVER `s` FOREIGN DATA WRAPPER `mysql` OPTIONS (HOST 'localhost', PORT 3306);", &CreateServerStatement{Name: "s", Wrapper: "mysql", Options: []ServerOption{ServerOption{Key: "HOST", Value: "localhost"}, ServerOption{Key: "PORT", Value: "3306"}}})
+	testGenStatement(t, "DROP SERVER `s`;", &DropServerStatement{Name: "s"})
+}
+
+func TestGenTransactionStatement(t *testing.T) {
+	testGenStatement(t, "START TRANSACTION READ WRITE;", &StartTransactionStatement{Characteristics: []string{"READ WRITE"}})
+	testGenStatement(t, "COMMIT AND CHAIN NO RELEASE;", &CommitStatement{Chain: "CHAIN", Release: "NO RELEASE"})
+	testGenStatement(t, "ROLLBACK;", &RollbackStatement{})
+	testGenStatement(t, "ROLLBACK TO SAVEPOINT `sp1`;", &RollbackToSavepointStatement{Name: "sp1"})
+	testGenStatement(t, "LOCK TABLES `hoge` WRITE, `fuga` AS `f` READ;", &LockTablesStatement{Locks: []TableLock{TableLock{TableName: TableNameIdentifier{Name: "hoge"}, Alias: "", Type: "WRITE"}, TableLock{TableName: TableNameIdentifier{Name: "fuga"}, Alias: "f", Type: "READ"}}})
+	testGenStatement(t, "ANALYZE NO_WRITE_TO_BINLOG TABLE `hoge`, `db`.`fuga`;", &AnalyzeTableStatement{NoWriteToBinlog: true, TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "hoge"}, TableNameIdentifier{Database: "db", Name: "fuga"}}})
+	testGenStatement(t, "CHECK TABLE `hoge` QUICK EXTENDED;", &CheckTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "hoge"}}, Options: []string{"QUICK", "EXTENDED"}})
+	testGenStatement(t, "FLUSH NO_WRITE_TO_BINLOG LOGS, HOSTS;", &FlushStatement{NoWriteToBinlog: true, Options: []string{"LOGS", "HOSTS"}})
+	testGenStatement(t, "FLUSH TABLES `hoge` WITH READ LOCK;", &FlushTablesStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "hoge"}}, Lock: "WITH READ LOCK"})
+}
+
+func TestGenDataManipulationStatement(t *testing.T) {
+	id := &ColumnExpression{ColumnName: ColumnNameIdentifier{Name: "id"}}
+	users := &TableReferenceTable{TableName: TableNameIdentifier{Name: "users"}}
+	testGenStatement(t, "SELECT /*+ BKA(u) */ DISTINCT `u`.*, COUNT(DISTINCT `id`) AS `cnt` FROM `users` AS `u` WHERE `id` NOT IN (?, ?) AND `name` LIKE ? GROUP BY `id` ORDER BY `id` DESC LIMIT 10 OFFSET ? LOCK IN SHARE MODE;", &SelectStatement{
+		Hints:    " BKA(u) ",
+		Distinct: true,
+		Fields: []SelectField{
+			SelectField{Expression: &StarExpression{TableName: TableNameIdentifier{Name: "u"}}},
+			SelectField{Expression: &FunctionCallExpression{Name: "COUNT", Arguments: []Expression{id}, Distinct: true}, Alias: "cnt"},
+		},
+		From:    []TableReference{&TableReferenceTable{TableName: TableNameIdentifier{Name: "users"}, Alias: "u"}},
+		Where:   &BinaryExpression{Operator: "AND", Left: &InExpression{Expression: id, Not: true, List: []Expression{&ParamExpression{Ordinal: 1}, &ParamExpression{Ordinal: 2}}}, Right: &LikeExpression{Expression: &ColumnExpression{ColumnName: ColumnNameIdentifier{Name: "name"}}, Pattern: &ParamExpression{Ordinal: 3}}},
+		GroupBy: []Expression{id},
+		OrderBy: []OrderByItem{OrderByItem{Expression: id, Desc: true}},
+		Limit:   &Limit{Count: &NumberExpression{Value: "10", Type: NUMBER_TYPE_INTEGER}, Offset: &ParamExpression{Ordinal: 4}},
+		Lock:    "LOCK IN SHARE MODE",
+	})
+	testGenStatement(t, "SELECT * FROM `users` LEFT JOIN `posts` USING (`id`) WHERE NOT EXISTS (SELECT 1) AND `deleted_at` IS NULL;", &SelectStatement{
+		Fields: []SelectField{SelectField{Expression: &StarExpression{}}},
+		From:   []TableReference{&TableReferenceJoin{Left: users, Type: "LEFT JOIN", Right: &TableReferenceTable{TableName: TableNameIdentifier{Name: "posts"}}, Using: []ColumnNameIdentifier{ColumnNameIdentifier{Name: "id"}}}},
+		Where:  &BinaryExpression{Operator: "AND", Left: &UnaryExpression{Operator: "NOT", Expression: &ExistsExpression{Select: &SelectStatement{Fields: []SelectField{SelectField{Expression: &NumberExpression{Value: "1", Type: NUMBER_TYPE_INTEGER}}}}}}, Right: &IsExpression{Expression: &ColumnExpression{ColumnName: ColumnNameIdentifier{Name: "deleted_at"}}, Not: false, Value: "NULL"}},
+	})
+	testGenStatement(t, "INSERT IGNORE INTO `users` (`id`) VALUES (?), (?) ON DUPLICATE KEY UPDATE `id` = VALUES(`id`);", &InsertStatement{
+		Ignore:               true,
+		TableName:            TableNameIdentifier{Name: "users"},
+		Columns:              []ColumnNameIdentifier{ColumnNameIdentifier{Name: "id"}},
+		Values:               [][]Expression{[]Expression{&ParamExpression{Ordinal: 1}}, []Expression{&ParamExpression{Ordinal: 2}}},
+		OnDuplicateKeyUpdate: []Assignment{Assignment{Column: id, Value: &FunctionCallExpression{Name: "VALUES", Arguments: []Expression{id}, Distinct: false}}},
+	})
+	testGenStatement(t, "REPLACE INTO `users` SET `id` = ?;", &InsertStatement{Replace: true, TableName: TableNameIdentifier{Name: "users"}, Assignments: []Assignment{Assignment{Column: id, Value: &ParamExpression{Ordinal: 1}}}})
+	testGenStatement(t, "UPDATE IGNORE `users` SET `id` = ? WHERE `id` BETWEEN ? AND ? LIMIT 1;", &UpdateStatement{
+		Ignore:      true,
+		Tables:      []TableReference{users},
+		Assignments: []Assignment{Assignment{Column: id, Value: &ParamExpression{Ordinal: 1}}},
+		Where:       &BetweenExpression{Expression: id, Not: false, From: &ParamExpression{Ordinal: 2}, To: &ParamExpression{Ordinal: 3}},
+		Limit:       &Limit{Count: &NumberExpression{Value: "1", Type: NUMBER_TYPE_INTEGER}},
+	})
+	testGenStatement(t, "DELETE FROM `users` USING `users`, `posts` WHERE `id` = TRUE;", &DeleteStatement{Tables: []TableNameIdentifier{TableNameIdentifier{Name: "users"}}, From: []TableReference{users, &TableReferenceTable{TableName: TableNameIdentifier{Name: "posts"}}}, Where: &BinaryExpression{Operator: "=", Left: id, Right: &BoolExpression{Value: true}}})
+	testGenStatement(t, "PREPARE `stmt1` FROM @sql;", &PrepareStatement{Name: "stmt1", Text: &UserVariableExpression{Name: "sql"}})
+	testGenStatement(t, "EXECUTE `stmt1` USING @a;", &ExecuteStatement{Name: "stmt1", Using: []Expression{&UserVariableExpression{Name: "a"}}})
+	testGenStatement(t, "DEALLOCATE PREPARE `stmt1`;", &DeallocatePrepareStatement{Name: "stmt1"})
+}
+
+func TestGenQueryExpression(t *testing.T) {
+	one := &SelectStatement{Fields: []SelectField{SelectField{Expression: &NumberExpression{Value: "1", Type: NUMBER_TYPE_INTEGER}}}}
+	two := &SelectStatement{Fields: []SelectField{SelectField{Expression: &NumberExpression{Value: "2", Type: NUMBER_TYPE_INTEGER}}}}
+	a := &ColumnExpression{ColumnName: ColumnNameIdentifier{Name: "a"}}
+	testGenStatement(t, "(SELECT 1) UNION ALL SELECT 2 ORDER BY 1 LIMIT 1;", &SetOperationStatement{Left: &ParenQueryExpression{Query: one}, Operator: "UNION", All: true, Right: two, OrderBy: []OrderByItem{OrderByItem{Expression: &NumberExpression{Value: "1", Type: NUMBER_TYPE_INTEGER}}}, Limit: &Limit{Count: &NumberExpression{Value: "1", Type: NUMBER_TYPE_INTEGER}}})
+	testGenStatement(t, "WITH RECURSIVE `cte` (`a`) AS (SELECT 1 INTERSECT SELECT 2) SELECT * FROM `cte` FOR UPDATE OF `cte` NOWAIT;", &SelectStatement{
+		With:       &WithClause{Recursive: true, CommonTableExpressions: []CommonTableExpression{CommonTableExpression{Name: "cte", Columns: []ColumnNameIdentifier{ColumnNameIdentifier{Name: "a"}}, Query: &SetOperationStatement{Left: one, Operator: "INTERSECT", Right: two}}}},
+		Fields:     []SelectField{SelectField{Expression: &StarExpression{}}},
+		From:       []TableReference{&TableReferenceTable{TableName: TableNameIdentifier{Name: "cte"}}},
+		Lock:       "FOR UPDATE",
+		LockOf:     []TableNameIdentifier{TableNameIdentifier{Name: "cte"}},
+		LockOption: "NOWAIT",
+	})
+	testGenStatement(t, "SELECT RANK() OVER (`w` ORDER BY `a` ROWS BETWEEN 1 PRECEDING AND UNBOUNDED FOLLOWING), COUNT(*) OVER `w` FROM LATERAL (SELECT 1) AS `t` WINDOW `w` AS (PARTITION BY `a`);", &SelectStatement{
+		Fields: []SelectField{
+			SelectField{Expression: &WindowFunctionExpression{Function: &FunctionCallExpression{Name: "RANK"}, Window: &WindowSpecification{Name: "w", OrderBy: []OrderByItem{OrderByItem{Expression: a}}, Frame: &WindowFrame{Unit: "ROWS", Start: WindowFrameBound{Type: "PRECEDING", Expression: &NumberExpression{Value: "1", Type: NUMBER_TYPE_INTEGER}}, End: &WindowFrameBound{Type: "UNBOUNDED FOLLOWING"}}}}},
+			SelectField{Expression: &WindowFunctionExpression{Function: &FunctionCallExpression{Name: "COUNT", Arguments: []Expression{&StarExpression{}}}, Window: &WindowSpecification{Name: "w"}}},
+		},
+		From:    []TableReference{&TableReferenceSubquery{Lateral: true, Select: one, Alias: "t"}},
+		Windows: []WindowDefinition{WindowDefinition{Name: "w", Window: &WindowSpecification{PartitionBy: []Expression{a}}}},
+	})
+	testGenStatement(t, "SELECT * FROM JSON_TABLE('[1]', '$[*]' COLUMNS (`i` FOR ORDINALITY, `v` INT PATH '$' ERROR ON ERROR, NESTED PATH '$.x' COLUMNS (`x` INT EXISTS PATH '$'))) AS `jt`;", &SelectStatement{
+		Fields: []SelectField{SelectField{Expression: &StarExpression{}}},
+		From: []TableReference{&TableReferenceJSONTable{Expression: &StringExpression{Value: "[1]", Introducer: ""}, Path: "$[*]", Columns: []JSONTableColumn{
+			JSONTableColumn{Name: "i", Ordinality: true},
+			JSONTableColumn{Name: "v", DataType: &DataTypeDefinitionNumber{Type: DATATYPE_INT}, Path: "$", OnError: "ERROR"},
+			JSONTableColumn{Path: "$.x", Nested: []JSONTableColumn{JSONTableColumn{Name: "x", DataType: &DataTypeDefinitionNumber{Type: DATATYPE_INT}, Exists: true, Path: "$"}}},
+		}, Alias: "jt"}},
+	})
+}
+
+func TestGenExplainShowStatement(t *testing.T) {
+	users := TableNameIdentifier{Name: "users"}
+	testGenStatement(t, "EXPLAIN ANALYZE FORMAT=TREE SELECT 1;", &ExplainStatement{Analyze: true, Format: "TREE", Statement: &SelectStatement{Fields: []SelectField{SelectField{Expression: &NumberExpression{Value: "1", Type: NUMBER_TYPE_INTEGER}}}}})
+	testGenStatement(t, "DESCRIBE `users` `id`;", &DescribeStatement{TableName: users, Column: "id"})
+	testGenStatement(t, "SHOW CREATE TABLE `db`.`users`;", &ShowCreateTableStatement{TableName: TableNameIdentifier{Database: "db", Name: "users"}})
+	testGenStatement(t, "SHOW FULL TABLES FROM `db` LIKE 'user%';", &ShowTablesStatement{Full: true, Database: DatabaseNameIdentifier{Name: "db"}, Filter: &ShowFilter{Like: "user%"}})
+	testGenStatement(t, "SHOW COLUMNS FROM `db`.`users`;", &ShowColumnsStatement{TableName: TableNameIdentifier{Database: "db", Name: "users"}})
+	testGenStatement(t, "SHOW INDEX FROM `users` WHERE `Key_name` = 'PRIMARY';", &ShowIndexStatement{TableName: users, Where: &BinaryExpression{Operator: "=", Left: &ColumnExpression{ColumnName: ColumnNameIdentifier{Name: "Key_name"}}, Right: &StringExpression{Value: "PRIMARY", Introducer: ""}}})
+	testGenStatement(t, "SHOW SESSION STATUS;", &ShowStatusStatement{Scope: "SESSION"})
+	testGenStatement(t, "SHOW WARNINGS LIMIT 5 OFFSET 10;", &ShowWarningsStatement{Limit: &Limit{Count: &NumberExpression{Value: "5", Type: NUMBER_TYPE_INTEGER}, Offset: &NumberExpression{Value: "10", Type: NUMBER_TYPE_INTEGER}}})
+	testGenStatement(t, "SHOW GRANTS FOR CURRENT_USER;", &ShowGrantsStatement{For: &AccountNameIdentifier{CurrentUser: true}})
+}
+
+func TestRedactedQuery(t *testing.T) {
+	for _, c := range []struct {
+		expect string
+		stmt   Statement
+	}{
+		{"CREATE USER `app`@`%` IDENTIFIED WITH caching_sha2_password BY '<secret>';", &CreateUserStatement{Users: []UserSpecification{UserSpecification{Account: AccountNameIdentifier{User: "app", Host: "%"}, Auth: &AuthOption{Plugin: "caching_sha2_password", Password: "secret"}}}}},
+		{"ALTER USER `app` IDENTIFIED WITH mysql_native_password AS '<secret>', `ro` IDENTIFIED BY RANDOM PASSWORD;", &AlterUserStatement{Users: []UserSpecification{
+			UserSpecification{Account: AccountNameIdentifier{User: "app"}, Auth: &AuthOption{Plugin: "mysql_native_password", AuthString: "*6BB4837EB74329105EE4568DDA7DC67ED2CA2AD9"}},
+			UserSpecification{Account: AccountNameIdentifier{User: "ro"}, Auth: &AuthOption{RandomPassword: true}},
+		}}},
+		{"/*!80000 SET PASSWORD = '<secret>' */;", &ExecutableCommentStatement{Version: 80000, Statement: &SetPasswordStatement{Account: nil, Password: "secret"}}},
+		{"ALTER SERVER `s` OPTIONS (USER 'remote', PASSWORD '<secret>');", &AlterServerStatement{Name: "s", Options: []ServerOption{ServerOption{Key: "USER", Value: "remote"}, ServerOption{Key: "PASSWORD", Value: "secret"}}}},
+		{"CREATE TABLE `t` (\n\t`id` INT \n) ENGINE=FEDERATED PASSWORD '<secret>' COMMENT 'secret';", &CreateTableStatement{
+			TableName:         TableNameIdentifier{Name: "t"},
+			CreateDefinitions: []CreateDefinition{&CreateDefinitionColumn{ColumnName: ColumnNameIdentifier{Name: "id"}, ColumnDefinition: ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionNumber{Type: DATATYPE_INT}, Nullable: true, Default: &DefaultDefinitionEmpty{}}}},
+			TableOptions:      []TableOption{&TableOptionName{Key: "ENGINE", Value: "FEDERATED"}, &TableOptionString{Key: "PASSWORD", Value: "secret"}, &TableOptionString{Key: "COMMENT", Value: "secret"}},
+		}},
+		{"ALTER TABLE `t` PASSWORD '<secret>', COMMENT 'secret';", &AlterTableStatement{TableName: TableNameIdentifier{Name: "t"}, AlterSpecifications: []AlterSpecification{
+			&AlterSpecificationTableOptions{TableOptions: []TableOption{&TableOptionString{Key: "PASSWORD", Value: "secret"}}},
+			&AlterSpecificationTableOptions{TableOptions: []TableOption{&TableOptionString{Key: "COMMENT", Value: "secret"}}},
+		}}},
+		{"DROP USER `app`;", &DropUserStatement{Users: []AccountNameIdentifier{AccountNameIdentifier{User: "app"}}}},
+	} {
+		if got := RedactedQuery(c.stmt); got != c.expect {
+			t.Errorf("Expect %q, but got %q", c.expect, got)
+		}
+	}
+}
+
+func TestGenSetStatement(t *testing.T) {
+	testGenStatement(t, "SET NAMES utf8mb4 COLLATE utf8mb4_bin;", &SetStatement{Assignments: []SetAssignment{&SetAssignmentNames{Charset: "utf8mb4", Collation: "utf8mb4_bin"}}})
+	testGenStatement(t, "SET CHARACTER SET DEFAULT;", &SetStatement{Assignments: []SetAssignment{&SetAssignmentCharset{Charset: ""}}})
+	testGenStatement(t, "SET @OLD_SQL_MODE = @@SQL_MODE, @@SESSION.SQL_MODE = 'NO_AUTO_VALUE_ON_ZERO';", &SetStatement{Assignments: []SetAssignment{
+		&SetAssignmentVariable{Variable: &UserVariableExpression{Name: "OLD_SQL_MODE"}, Value: &SystemVariableExpression{Scope: "", Name: "SQL_MODE"}},
+		&SetAssignmentVariable{Variable: &SystemVariableExpression{Scope: "SESSION", Name: "SQL_MODE"}, Value: &StringExpression{Value: "NO_AUTO_VALUE_ON_ZERO", Introducer: ""}},
+	}})
+}
+
+func TestGenExecutableCommentStatement(t *testing.T) {
+	testGenStatement(t, "/*!40101 SET NAMES utf8 */;", &ExecutableCommentStatement{Version: 40101, Statement: &SetStatement{Assignments: []SetAssignment{&SetAssignmentNames{Charset: "utf8", Collation: ""}}}})
+	testGenStatement(t, "/*! DROP TABLE `hoge` */;", &ExecutableCommentStatement{Version: 0, Statement: &DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "hoge"}}}})
+	testGenStatement(t, "/*M!100100 DROP TABLE `hoge` */;", &ExecutableCommentStatement{Version: 100100, MariaDB: true, Statement: &DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "hoge"}}}})
+	testGenStatement(t, "/* hoge */;", &CommentStatement{Content: " hoge "})
+	testGenTableOption(t, "/*!50100 ENGINE=InnoDB */", &TableOptionName{VersionGate: VersionGate{Enclosed: true, Version: 50100}, Key: "ENGINE", Value: "InnoDB"})
+	testGenTableOption(t, "/*M!100100 PAGE_CHECKSUM=1 */", &TableOptionNumber{VersionGate: VersionGate{Enclosed: true, Version: 100100, MariaDB: true}, Key: "PAGE_CHECKSUM", Value: 1})
+	testGenTableOption(t, "/*! COMMENT 'x' */", &TableOptionString{VersionGate: VersionGate{Enclosed: true}, Key: "COMMENT", Value: "x"})
+}
+
+func TestGenPartition(t *testing.T) {
+	testGenStatement(t, "CREATE TABLE `hoge` (\n\t`id` INT \n) ENGINE=InnoDB\n/*!50100 PARTITION BY RANGE (TO_DAYS(`created_at`))\n(PARTITION `p0` VALUES LESS THAN (735964),\n PARTITION `p1` VALUES LESS THAN (MAXVALUE) ENGINE=InnoDB) */;", &CreateTableStatement{TableName: TableNameIdentifier{Name: "hoge", Database: ""}, CreateDefinitions: []CreateDefinition{
+		&CreateDefinitionColumn{ColumnName: ColumnNameIdentifier{Name: "id"}, ColumnDefinition: ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionNumber{Type: DATATYPE_INT, Length: 0, Unsigned: false, Zerofill: false}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionEmpty{}}},
+	}, TableOptions: []TableOption{&TableOptionName{Key: "ENGINE", Value: "InnoDB"}}, PartitionOptions: &PartitionOptions{
+		PartitionBy: PartitionMethod{Type: PARTITION_TYPE_RANGE, Expression: &FunctionCallExpression{Name: "TO_DAYS", Arguments: []Expression{&ColumnExpression{ColumnName: ColumnNameIdentifier{Name: "created_at"}}}, Distinct: false}},
+		Definitions: []PartitionDefinition{
+			PartitionDefinition{Name: PartitionNameIdentifier{Name: "p0"}, ValuesLessThan: []Expression{&NumberExpression{Value: "735964", Type: NUMBER_TYPE_INTEGER}}},
+			PartitionDefinition{Name: PartitionNameIdentifier{Name: "p1"}, ValuesLessThan: []Expression{&MaxValueExpression{}}, Options: []TableOption{&TableOptionName{Key: "ENGINE", Value: "InnoDB"}}},
+		},
+		VersionGate: VersionGate{Enclosed: true, Version: 50100},
+	}})
+	testGenStatement(t, "ALTER TABLE `hoge` PARTITION BY LINEAR KEY ALGORITHM=2 (`id`) PARTITIONS 4;", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge"}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationPartitionBy{PartitionOptions: &PartitionOptions{PartitionBy: PartitionMethod{Type: PARTITION_TYPE_KEY, Linear: true, Algorithm: 2, Columns: []ColumnNameIdentifier{ColumnNameIdentifier{Name: "id"}}}, Partitions: 4}},
+	}})
+	testGenStatement(t, "ALTER TABLE `hoge` DROP `fuga` PARTITION BY HASH (`id` % 4);", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge"}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationDropColumn{ColumnName: ColumnNameIdentifier{Name: "fuga"}},
+		&AlterSpecificationPartitionBy{PartitionOptions: &PartitionOptions{PartitionBy: PartitionMethod{Type: PARTITION_TYPE_HASH, Expression: &BinaryExpression{Operator: "%", Left: &ColumnExpression{ColumnName: ColumnNameIdentifier{Name: "id"}}, Right: &NumberExpression{Value: "4", Type: NUMBER_TYPE_INTEGER}}}}},
+	}})
+	testGenStatement(t, "ALTER TABLE `hoge` REORGANIZE PARTITION `p0` INTO (PARTITION `p0` VALUES IN (1, 2), PARTITION `p1` VALUES IN (3));", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge"}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationReorganizePartition{Names: []PartitionNameIdentifier{PartitionNameIdentifier{Name: "p0"}}, Definitions: []PartitionDefinition{
+			PartitionDefinition{Name: PartitionNameIdentifier{Name: "p0"}, ValuesIn: []Expression{&NumberExpression{Value: "1", Type: NUMBER_TYPE_INTEGER}, &NumberExpression{Value: "2", Type: NUMBER_TYPE_INTEGER}}},
+			PartitionDefinition{Name: PartitionNameIdentifier{Name: "p1"}, ValuesIn: []Expression{&NumberExpression{Value: "3", Type: NUMBER_TYPE_INTEGER}}},
+		}},
+	}})
+	testGenStatement(t, "ALTER TABLE `hoge` EXCHANGE PARTITION `p0` WITH TABLE `fuga`;", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge"}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationExchangePartition{Name: PartitionNameIdentifier{Name: "p0"}, TableName: TableNameIdentifier{Name: "fuga"}, WithoutValidation: false},
+	}})
+	testGenStatement(t, "ALTER TABLE `hoge` TRUNCATE PARTITION `p0`, `p1`;", &AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge"}, AlterSpecifications: []AlterSpecification{
+		&AlterSpecificationTruncatePartition{Names: []PartitionNameIdentifier{PartitionNameIdentifier{Name: "p0"}, PartitionNameIdentifier{Name: "p1"}}, All: false},
+	}})
 }
 
 func TestGenColumnDefinition(t *testing.T) {
-	testGenColumnDefinition(t, "INT DEFAULT NULL", ColumnDefinition{&DataTypeDefinitionNumber{DATATYPE_INT, 0, false, false}, true, false, &DefaultDefinitionNull{}})
-	testGenColumnDefinition(t, "INT(10) UNSIGNED DEFAULT NULL", ColumnDefinition{&DataTypeDefinitionNumber{DATATYPE_INT, 10, true, false}, true, false, &DefaultDefinitionNull{}})
-	testGenColumnDefinition(t, "INT(10) UNSIGNED ZEROFILL DEFAULT NULL", ColumnDefinition{&DataTypeDefinitionNumber{DATATYPE_INT, 10, true, true}, true, false, &DefaultDefinitionNull{}})
-	testGenColumnDefinition(t, "DATE ", ColumnDefinition{&DataTypeDefinitionSimple{DATATYPE_DATE}, true, false, &DefaultDefinitionEmpty{}})
-	testGenColumnDefinition(t, "DATE DEFAULT \"2015/01/04\"", ColumnDefinition{&DataTypeDefinitionSimple{DATATYPE_DATE}, true, false, &DefaultDefinitionString{"2015/01/04"}})
-	testGenColumnDefinition(t, "DATE DEFAULT CURRENT_TIMESTAMP", ColumnDefinition{&DataTypeDefinitionSimple{DATATYPE_DATE}, true, false, &DefaultDefinitionCurrentTimestamp{}})
-	testGenColumnDefinition(t, "DATE DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP", ColumnDefinition{&DataTypeDefinitionSimple{DATATYPE_DATE}, true, false, &DefaultDefinitionCurrentTimestamp{true}})
+	testGenColumnDefinition(t, "INT DEFAULT NULL", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionNumber{Type: DATATYPE_INT, Length: 0, Unsigned: false, Zerofill: false}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionNull{}})
+	testGenColumnDefinition(t, "INT(10) UNSIGNED DEFAULT NULL", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionNumber{Type: DATATYPE_INT, Length: 10, Unsigned: true, Zerofill: false}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionNull{}})
+	testGenColumnDefinition(t, "INT(10) UNSIGNED ZEROFILL DEFAULT NULL", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionNumber{Type: DATATYPE_INT, Length: 10, Unsigned: true, Zerofill: true}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionNull{}})
+	testGenColumnDefinition(t, "DATE ", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionSimple{Type: DATATYPE_DATE}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionEmpty{}})
+	testGenColumnDefinition(t, "DOUBLE DEFAULT -1.5e3", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionFraction{Type: DATATYPE_DOUBLE, Length: 0, Decimals: 0, Unsigned: false, Zerofill: false}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionNumber{Value: NumberExpression{Value: "-1.5e3", Type: NUMBER_TYPE_FLOAT}}})
+	testGenColumnDefinition(t, "DATE DEFAULT '2015/01/04'", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionSimple{Type: DATATYPE_DATE}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionString{Value: "2015/01/04"}})
+	testGenColumnDefinition(t, "DATE DEFAULT CURRENT_TIMESTAMP", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionSimple{Type: DATATYPE_DATE}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionCurrentTimestamp{}})
+	testGenColumnDefinition(t, "DATE DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionSimple{Type: DATATYPE_DATE}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionCurrentTimestamp{OnUpdate: true}})
+
+	testGenColumnDefinition(t, "DECIMAL(10, 2) UNSIGNED ZEROFILL DEFAULT NULL", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionFraction{Type: DATATYPE_DECIMAL, Length: 10, Decimals: 2, Unsigned: true, Zerofill: true}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionNull{}})
+	testGenColumnDefinition(t, "DECIMAL(10) UNSIGNED DEFAULT NULL", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionFraction{Type: DATATYPE_DECIMAL, Length: 10, Decimals: 0, Unsigned: true, Zerofill: false}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionNull{}})
+	testGenColumnDefinition(t, "DECIMAL DEFAULT NULL", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionFraction{Type: DATATYPE_DECIMAL, Length: 0, Decimals: 0, Unsigned: false, Zerofill: false}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionNull{}})
 
-	testGenColumnDefinition(t, "DECIMAL(10, 2) UNSIGNED ZEROFILL DEFAULT NULL", ColumnDefinition{&DataTypeDefinitionFraction{DATATYPE_DECIMAL, 10, 2, true, true}, true, false, &DefaultDefinitionNull{}})
-	testGenColumnDefinition(t, "DECIMAL(10) UNSIGNED DEFAULT NULL", ColumnDefinition{&DataTypeDefinitionFraction{DATATYPE_DECIMAL, 10, 0, true, false}, true, false, &DefaultDefinitionNull{}})
-	testGenColumnDefinition(t, "DECIMAL DEFAULT NULL", ColumnDefinition{&DataTypeDefinitionFraction{DATATYPE_DECIMAL, 0, 0, false, false}, true, false, &DefaultDefinitionNull{}})
+	testGenColumnDefinition(t, "VARCHAR(255) DEFAULT NULL", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionString{Type: DATATYPE_VARCHAR, Length: 255, CharsetName: "", CollationName: ""}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionNull{}})
+	testGenColumnDefinition(t, "VARCHAR(255) CHARACTER SET utf8mb4 DEFAULT NULL", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionString{Type: DATATYPE_VARCHAR, Length: 255, CharsetName: "utf8mb4", CollationName: ""}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionNull{}})
+	testGenColumnDefinition(t, "VARCHAR(255) COLLATE utf8mb4_general_ci DEFAULT NULL", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionString{Type: DATATYPE_VARCHAR, Length: 255, CharsetName: "", CollationName: "utf8mb4_general_ci"}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionNull{}})
 
-	testGenColumnDefinition(t, "VARCHAR(255) DEFAULT NULL", ColumnDefinition{&DataTypeDefinitionString{DATATYPE_VARCHAR, 255, "", ""}, true, false, &DefaultDefinitionNull{}})
-	testGenColumnDefinition(t, "VARCHAR(255) CHARACTER SET utf8mb4 DEFAULT NULL", ColumnDefinition{&DataTypeDefinitionString{DATATYPE_VARCHAR, 255, "utf8mb4", ""}, true, false, &DefaultDefinitionNull{}})
-	testGenColumnDefinition(t, "VARCHAR(255) COLLATE utf8mb4_general_ci DEFAULT NULL", ColumnDefinition{&DataTypeDefinitionString{DATATYPE_VARCHAR, 255, "", "utf8mb4_general_ci"}, true, false, &DefaultDefinitionNull{}})
+	testGenColumnDefinition(t, "TEXT CHARACTER SET utf8mb4 ", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionTextBlob{Type: DATATYPE_TEXT, Binary: false, CharsetName: "utf8mb4", CollationName: ""}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionEmpty{}})
+	testGenColumnDefinition(t, "TEXT BINARY COLLATE utf8mb4_general_ci ", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionTextBlob{Type: DATATYPE_TEXT, Binary: true, CharsetName: "", CollationName: "utf8mb4_general_ci"}, Nullable: true, AutoIncrement: false, Default: &DefaultDefinitionEmpty{}})
 
-	testGenColumnDefinition(t, "TEXT CHARACTER SET utf8mb4 ", ColumnDefinition{&DataTypeDefinitionTextBlob{DATATYPE_TEXT, false, "utf8mb4", ""}, true, false, &DefaultDefinitionEmpty{}})
-	testGenColumnDefinition(t, "TEXT BINARY COLLATE utf8mb4_general_ci ", ColumnDefinition{&DataTypeDefinitionTextBlob{DATATYPE_TEXT, true, "", "utf8mb4_general_ci"}, true, false, &DefaultDefinitionEmpty{}})
+	total := &BinaryExpression{Operator: "*", Left: &ColumnExpression{ColumnName: ColumnNameIdentifier{Name: "price"}}, Right: &ColumnExpression{ColumnName: ColumnNameIdentifier{Name: "quantity"}}}
+	testGenColumnDefinition(t, "INT GENERATED ALWAYS AS (`price` * `quantity`) VIRTUAL ", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionNumber{Type: DATATYPE_INT}, Nullable: true, Default: &DefaultDefinitionEmpty{}, Generated: total})
+	testGenColumnDefinition(t, "INT GENERATED ALWAYS AS (`price` * `quantity`) STORED NOT NULL ", ColumnDefinition{DataTypeDefinition: &DataTypeDefinitionNumber{Type: DATATYPE_INT}, Nullable: false, Default: &DefaultDefinitionEmpty{}, Generated: total, Stored: true})
 }
 
 func testGenStatement(t *testing.T, expected string, input Statement) {
@@ -84,9 +408,9 @@ func testGenStatement(t *testing.T, expected string, input Statement) {
 }
 
 func testGenColumnDefinition(t *testing.T, expected string, input ColumnDefinition) {
-	specAddColumn := AlterSpecificationAddColumn{ColumnNameIdentifier{"foo"}, ColumnDefinition{}}
+	specAddColumn := AlterSpecificationAddColumn{ColumnName: ColumnNameIdentifier{Name: "foo"}, ColumnDefinition: ColumnDefinition{}}
 	specAddColumn.ColumnDefinition = input
-	statement := AlterTableStatement{TableNameIdentifier{Name: "hoge", Database: ""}, []AlterSpecification{}}
+	statement := AlterTableStatement{TableName: TableNameIdentifier{Name: "hoge", Database: ""}, AlterSpecifications: []AlterSpecification{}}
 	statement.AlterSpecifications = append(statement.AlterSpecifications, &specAddColumn)
 	testGenStatement(t, "ALTER TABLE `hoge` ADD `foo` "+expected+";", &statement)
 }
@@ -0,0 +1,136 @@
+package mysql
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// parseCorpus is the files in testdata which are parsed without errors:
+// dumps of plain, partitioned and generated-column tables, and a schema
+// with long comments.
+var parseCorpus = []string{
+	"schema.sql",
+	"mysqldump.sql",
+	"partitions.sql",
+	"generated_columns.sql",
+	"comments.sql",
+}
+
+// routinesCorpus is a dump with stored programs in DELIMITER blocks, which
+// are split into statements but not supported by the parser.
+const routinesCorpus = "routines.sql"
+
+// loadCorpus returns the files in testdata concatenated and repeated n
+// times as a large source.
+func loadCorpus(tb testing.TB, n int, files ...string) string {
+	var src strings.Builder
+	for _, file := range files {
+		data, err := os.ReadFile("testdata/" + file)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		src.Write(data)
+	}
+	return strings.Repeat(src.String(), n)
+}
+
+// allCorpus returns all the files in testdata repeated n times.
+func allCorpus(tb testing.TB, n int) string {
+	return loadCorpus(tb, n, append(parseCorpus, routinesCorpus)...)
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, file := range parseCorpus {
+		b.Run(strings.TrimSuffix(file, ".sql"), func(b *testing.B) {
+			src := loadCorpus(b, 20, file)
+			b.SetBytes(int64(len(src)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s := new(Scanner)
+				s.Init(src)
+				if _, err := Parse(s); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParseParallel(b *testing.B) {
+	src := loadCorpus(b, 1, parseCorpus...)
+	b.SetBytes(int64(len(src)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s := new(Scanner)
+			s.Init(src)
+			if _, err := Parse(s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkParseWithRecovery(b *testing.B) {
+	src := allCorpus(b, 20)
+	b.SetBytes(int64(len(src)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := new(Scanner)
+		s.Init(src)
+		ParseWithRecovery(s)
+	}
+}
+
+func BenchmarkScan(b *testing.B) {
+	src := allCorpus(b, 20)
+	b.SetBytes(int64(len(src)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := new(Scanner)
+		s.Init(src)
+		for {
+			tok, _, _ := s.Scan()
+			if tok == EOF {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkSplit(b *testing.B) {
+	src := allCorpus(b, 20)
+	b.SetBytes(int64(len(src)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := new(Scanner)
+		s.Init(src)
+		Split(s)
+	}
+}
+
+func BenchmarkStatementReader(b *testing.B) {
+	src := allCorpus(b, 20)
+	b.SetBytes(int64(len(src)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := NewStatementReader(strings.NewReader(src))
+		for {
+			_, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			if _, ok := err.(*ParseError); err != nil && !ok {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package mysql
+
+import "strings"
+
+// SQLMode is a set of the SQL modes which affect parsing.
+type SQLMode uint
+
+const (
+	SQL_MODE_ANSI_QUOTES SQLMode = 1 << iota
+	SQL_MODE_PIPES_AS_CONCAT
+	SQL_MODE_NO_BACKSLASH_ESCAPES
+	SQL_MODE_IGNORE_SPACE
+	SQL_MODE_HIGH_NOT_PRECEDENCE
+)
+
+var sqlModeNames = []struct {
+	mode SQLMode
+	name string
+}{
+	{SQL_MODE_ANSI_QUOTES, "ANSI_QUOTES"},
+	{SQL_MODE_PIPES_AS_CONCAT, "PIPES_AS_CONCAT"},
+	{SQL_MODE_NO_BACKSLASH_ESCAPES, "NO_BACKSLASH_ESCAPES"},
+	{SQL_MODE_IGNORE_SPACE, "IGNORE_SPACE"},
+	{SQL_MODE_HIGH_NOT_PRECEDENCE, "HIGH_NOT_PRECEDENCE"},
+}
+
+// String returns the modes separated by ',' like the sql_mode variable.
+func (m SQLMode) String() string {
+	var names []string
+	for _, mode := range sqlModeNames {
+		if m&mode.mode != 0 {
+			names = append(names, mode.name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// ParseSQLMode parses the value of sql_mode variable such as
+// "ANSI_QUOTES,STRICT_TRANS_TABLES". Modes which don't affect parsing are
+// ignored, and the combination mode ANSI is expanded.
+func ParseSQLMode(value string) SQLMode {
+	var m SQLMode
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "ANSI" {
+			m |= SQL_MODE_ANSI_QUOTES | SQL_MODE_PIPES_AS_CONCAT | SQL_MODE_IGNORE_SPACE
+			continue
+		}
+		for _, mode := range sqlModeNames {
+			if name == mode.name {
+				m |= mode.mode
+			}
+		}
+	}
+	return m
+}
+
+type Dialect uint
+
+const (
+	DIALECT_MYSQL Dialect = iota
+	DIALECT_MARIADB
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case DIALECT_MYSQL:
+		return "MYSQL"
+	case DIALECT_MARIADB:
+		return "MARIADB"
+	default:
+		return ""
+	}
+}
+
+// ParserConfig is the settings of the target server. The zero value
+// parses statements for the latest MySQL with the default SQL mode.
+type ParserConfig struct {
+	// ServerVersion is the version of the target server such as 50709 for
+	// 5.7.9. See Scanner.ServerVersion.
+	ServerVersion uint
+	SQLMode       SQLMode
+	Dialect       Dialect
+}
+
+// ParserOption changes ParserConfig.
+type ParserOption func(*ParserConfig)
+
+func WithServerVersion(version uint) ParserOption {
+	return func(c *ParserConfig) {
+		c.ServerVersion = version
+	}
+}
+
+func WithSQLMode(mode SQLMode) ParserOption {
+	return func(c *ParserConfig) {
+		c.SQLMode = mode
+	}
+}
+
+func WithDialect(dialect Dialect) ParserOption {
+	return func(c *ParserConfig) {
+		c.Dialect = dialect
+	}
+}
+
+func NewParserConfig(opts ...ParserOption) ParserConfig {
+	var c ParserConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// NewScanner returns a scanner over src with the settings.
+func (c ParserConfig) NewScanner(src string) *Scanner {
+	s := new(Scanner)
+	c.configure(s)
+	s.Init(src)
+	return s
+}
+
+func (c ParserConfig) configure(s *Scanner) {
+	s.ServerVersion = c.ServerVersion
+	s.ANSIQuotes = c.SQLMode&SQL_MODE_ANSI_QUOTES != 0
+	s.PipesAsConcat = c.SQLMode&SQL_MODE_PIPES_AS_CONCAT != 0
+	s.NoBackslashEscapes = c.SQLMode&SQL_MODE_NO_BACKSLASH_ESCAPES != 0
+	s.HighNotPrecedence = c.SQLMode&SQL_MODE_HIGH_NOT_PRECEDENCE != 0
+	s.IgnoreSpace = c.SQLMode&SQL_MODE_IGNORE_SPACE != 0
+	s.MariaDB = c.Dialect == DIALECT_MARIADB
+}
+
+// ParseString parses src with the options.
+func ParseString(src string, opts ...ParserOption) ([]Statement, error) {
+	return Parse(NewParserConfig(opts...).NewScanner(src))
+}
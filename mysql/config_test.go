@@ -0,0 +1,67 @@
+package mysql
+
+import "testing"
+
+func testParseString(t *testing.T, src string, expect string, opts ...ParserOption) {
+	statements, err := ParseString(src, opts...)
+	if err != nil {
+		t.Errorf("Unexpected error for %q: %s", src, err)
+		return
+	}
+	if len(statements) != 1 {
+		t.Errorf("Expect 1 statement for %q, but got %d", src, len(statements))
+		return
+	}
+	if query := statements[0].ToQuery(); query != expect {
+		t.Errorf("Expect %q for %q, but got %q", expect, src, query)
+	}
+}
+
+func TestParseStringSQLMode(t *testing.T) {
+	testParseString(t, "SELECT a || b * c FROM t;", "SELECT `a` OR `b` * `c` FROM `t`;")
+	testParseString(t, "SELECT a || b * c FROM t;", "SELECT CONCAT(`a`, `b`) * `c` FROM `t`;", WithSQLMode(SQL_MODE_PIPES_AS_CONCAT))
+	testParseString(t, "SELECT \"a\" FROM t;", "SELECT 'a' FROM `t`;")
+	testParseString(t, "SELECT \"a\" FROM t;", "SELECT `a` FROM `t`;", WithSQLMode(SQL_MODE_ANSI_QUOTES))
+	testParseString(t, "SELECT 'a\\'b';", "SELECT 'a''b';")
+	testParseString(t, "SELECT 'a\\';", "SELECT 'a\\\\';", WithSQLMode(SQL_MODE_NO_BACKSLASH_ESCAPES))
+
+	src := "SELECT * FROM t WHERE NOT a BETWEEN 1 AND 2 AND NOT NOT b + 1 = c AND NOT d;"
+	testParseString(t, src, "SELECT * FROM `t` WHERE NOT `a` BETWEEN 1 AND 2 AND NOT NOT `b` + 1 = `c` AND NOT `d`;")
+	testParseString(t, src, "SELECT * FROM `t` WHERE (NOT `a`) BETWEEN 1 AND 2 AND (NOT (NOT `b`)) + 1 = `c` AND NOT `d`;", WithSQLMode(SQL_MODE_HIGH_NOT_PRECEDENCE))
+}
+
+func TestParseStringServerVersion(t *testing.T) {
+	if _, err := ParseString("CREATE TABLE rank (id INT);"); err == nil {
+		t.Errorf("Expect RANK to be reserved for MySQL 8.0")
+	}
+	testParseString(t, "CREATE TABLE rank (id INT);", "CREATE TABLE `rank` (\n\t`id` INT \n) ;", WithServerVersion(50709))
+	testParseString(t, "CREATE TABLE rank (id INT);", "CREATE TABLE `rank` (\n\t`id` INT \n) ;", WithDialect(DIALECT_MARIADB))
+
+	src := "/*M!100100 DROP TABLE a */;"
+	testParseString(t, src, "/*M!100100 DROP TABLE a */;")
+	testParseString(t, src, "/*M!100100 DROP TABLE `a` */;", WithDialect(DIALECT_MARIADB))
+	testParseString(t, "/*!100100 DROP TABLE a */;", "/*!100100 DROP TABLE `a` */;", WithDialect(DIALECT_MARIADB))
+	testParseString(t, src, "/*M!100100 DROP TABLE a */;", WithDialect(DIALECT_MARIADB), WithServerVersion(100000))
+}
+
+func TestParseSQLMode(t *testing.T) {
+	mode := ParseSQLMode("ansi, STRICT_TRANS_TABLES,HIGH_NOT_PRECEDENCE")
+	if expect := "ANSI_QUOTES,PIPES_AS_CONCAT,IGNORE_SPACE,HIGH_NOT_PRECEDENCE"; mode.String() != expect {
+		t.Errorf("Expect %q, but got %q", expect, mode.String())
+	}
+}
+
+func TestParseStringIgnoreSpace(t *testing.T) {
+	testParseString(t, "SELECT COUNT(*), now() FROM t;", "SELECT COUNT(*), now() FROM `t`;")
+	testParseString(t, "SELECT COUNT (*), now () FROM t;", "SELECT COUNT(*), now() FROM `t`;", WithSQLMode(SQL_MODE_IGNORE_SPACE))
+	testParseString(t, "SELECT my_func (1);", "SELECT my_func(1);")
+	testParseString(t, "CREATE TABLE count (id INT);", "CREATE TABLE `count` (\n\t`id` INT \n) ;")
+
+	_, err := ParseString("SELECT COUNT (*) FROM t;")
+	if e, ok := err.(*ParseError); !ok || e.Message != "space after built-in function name" || e.Literal != "COUNT" {
+		t.Errorf("Expect the error about the space after COUNT, but got %#v", err)
+	}
+	if _, err := ParseString("CREATE TABLE count (id INT);", WithSQLMode(SQL_MODE_IGNORE_SPACE)); err == nil {
+		t.Errorf("Expect COUNT to be reserved with IGNORE_SPACE")
+	}
+}
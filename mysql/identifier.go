@@ -0,0 +1,71 @@
+package mysql
+
+import (
+	"strings"
+)
+
+// LowerCaseTableNames is the value of lower_case_table_names system
+// variable, which decides whether table and database names are case
+// sensitive.
+type LowerCaseTableNames uint
+
+const (
+	// names are stored as given and compared case-sensitively.
+	LOWER_CASE_TABLE_NAMES_SENSITIVE LowerCaseTableNames = iota
+	// names are stored in lowercase and compared case-insensitively.
+	LOWER_CASE_TABLE_NAMES_LOWERCASE
+	// names are stored as given but compared in lowercase.
+	LOWER_CASE_TABLE_NAMES_INSENSITIVE
+)
+
+func (l LowerCaseTableNames) normalize(name string) string {
+	if l == LOWER_CASE_TABLE_NAMES_SENSITIVE {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// Normalize returns the table name as MySQL compares it, which is usable
+// as a key of map. The span is not kept.
+func (x *TableNameIdentifier) Normalize(l LowerCaseTableNames) TableNameIdentifier {
+	return TableNameIdentifier{Name: l.normalize(x.Name), Database: l.normalize(x.Database)}
+}
+
+// Equal reports whether x and y are the same table. Database names are
+// compared as well, so that a name without database matches only a name
+// without database.
+func (x *TableNameIdentifier) Equal(y TableNameIdentifier, l LowerCaseTableNames) bool {
+	return x.Normalize(l) == y.Normalize(l)
+}
+
+// Normalize returns the database name as MySQL compares it.
+func (x *DatabaseNameIdentifier) Normalize(l LowerCaseTableNames) DatabaseNameIdentifier {
+	return DatabaseNameIdentifier{Name: l.normalize(x.Name)}
+}
+
+// Equal reports whether x and y are the same database.
+func (x *DatabaseNameIdentifier) Equal(y DatabaseNameIdentifier, l LowerCaseTableNames) bool {
+	return x.Normalize(l) == y.Normalize(l)
+}
+
+// Normalize returns the column name in lowercase since column names are
+// not case sensitive.
+func (x *ColumnNameIdentifier) Normalize() ColumnNameIdentifier {
+	return ColumnNameIdentifier{Name: strings.ToLower(x.Name)}
+}
+
+// Equal reports whether x and y are the same column.
+func (x *ColumnNameIdentifier) Equal(y ColumnNameIdentifier) bool {
+	return x.Normalize() == y.Normalize()
+}
+
+// Normalize returns the index name in lowercase since index names are
+// not case sensitive.
+func (x *IndexNameIdentifier) Normalize() IndexNameIdentifier {
+	return IndexNameIdentifier{Name: strings.ToLower(x.Name)}
+}
+
+// Equal reports whether x and y are the same index.
+func (x *IndexNameIdentifier) Equal(y IndexNameIdentifier) bool {
+	return x.Normalize() == y.Normalize()
+}
@@ -0,0 +1,54 @@
+package mysql
+
+import (
+	"testing"
+)
+
+func TestTableNameIdentifierEqual(t *testing.T) {
+	for _, c := range []struct {
+		x, y   TableNameIdentifier
+		l      LowerCaseTableNames
+		expect bool
+	}{
+		{TableNameIdentifier{Name: "users"}, TableNameIdentifier{Name: "users"}, LOWER_CASE_TABLE_NAMES_SENSITIVE, true},
+		{TableNameIdentifier{Name: "Users"}, TableNameIdentifier{Name: "users"}, LOWER_CASE_TABLE_NAMES_SENSITIVE, false},
+		{TableNameIdentifier{Name: "Users"}, TableNameIdentifier{Name: "users"}, LOWER_CASE_TABLE_NAMES_LOWERCASE, true},
+		{TableNameIdentifier{Name: "Users"}, TableNameIdentifier{Name: "USERS"}, LOWER_CASE_TABLE_NAMES_INSENSITIVE, true},
+		{TableNameIdentifier{Name: "users", Database: "App"}, TableNameIdentifier{Name: "users", Database: "app"}, LOWER_CASE_TABLE_NAMES_SENSITIVE, false},
+		{TableNameIdentifier{Name: "users", Database: "App"}, TableNameIdentifier{Name: "USERS", Database: "app"}, LOWER_CASE_TABLE_NAMES_INSENSITIVE, true},
+		{TableNameIdentifier{Name: "users", Database: "app"}, TableNameIdentifier{Name: "users"}, LOWER_CASE_TABLE_NAMES_INSENSITIVE, false},
+	} {
+		if result := c.x.Equal(c.y, c.l); result != c.expect {
+			t.Errorf("Expect %+v.Equal(%+v, %d) to be %v, but got %v", c.x, c.y, c.l, c.expect, result)
+		}
+	}
+
+	tables := map[TableNameIdentifier]bool{}
+	users := TableNameIdentifier{Name: "Users"}
+	tables[users.Normalize(LOWER_CASE_TABLE_NAMES_LOWERCASE)] = true
+	lower := TableNameIdentifier{Name: "users"}
+	if !tables[lower.Normalize(LOWER_CASE_TABLE_NAMES_LOWERCASE)] {
+		t.Errorf("Expect normalized names to be usable as map keys")
+	}
+}
+
+func TestDatabaseNameIdentifierEqual(t *testing.T) {
+	app := DatabaseNameIdentifier{Name: "App"}
+	if app.Equal(DatabaseNameIdentifier{Name: "app"}, LOWER_CASE_TABLE_NAMES_SENSITIVE) {
+		t.Errorf("Expect database names to be case sensitive when lower_case_table_names=0")
+	}
+	if !app.Equal(DatabaseNameIdentifier{Name: "app"}, LOWER_CASE_TABLE_NAMES_LOWERCASE) {
+		t.Errorf("Expect database names not to be case sensitive when lower_case_table_names=1")
+	}
+}
+
+func TestColumnAndIndexNameIdentifierEqual(t *testing.T) {
+	column := ColumnNameIdentifier{Name: "UserID"}
+	if !column.Equal(ColumnNameIdentifier{Name: "userid"}) || column.Equal(ColumnNameIdentifier{Name: "user_id"}) {
+		t.Errorf("Expect column names to be compared case-insensitively")
+	}
+	index := IndexNameIdentifier{Name: "IDX_Name"}
+	if !index.Equal(IndexNameIdentifier{Name: "idx_name"}) || index.Equal(IndexNameIdentifier{Name: "idx_name2"}) {
+		t.Errorf("Expect index names to be compared case-insensitively")
+	}
+}
@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"strings"
+)
+
+// CausesImplicitCommit returns true if MySQL commits the current transaction
+// implicitly before executing stmt, such as DDL or account management
+// statements.
+//
+// SET autocommit = 1 is reported as well although it commits only when
+// autocommit is disabled. UNLOCK TABLES is reported because it commits when
+// tables are locked by LOCK TABLES.
+func CausesImplicitCommit(stmt Statement) bool {
+	switch x := stmt.(type) {
+	case *ExecutableCommentStatement:
+		return CausesImplicitCommit(x.Statement)
+	case *CreateDatabaseStatement, *DropDatabaseStatement,
+		*CreateTableStatement, *AlterTableStatement, *DropTableStatement,
+		*CreateTablespaceStatement, *AlterTablespaceStatement, *DropTablespaceStatement,
+		*CreateLogfileGroupStatement, *AlterLogfileGroupStatement, *DropLogfileGroupStatement,
+		*CreateServerStatement, *AlterServerStatement, *DropServerStatement:
+		return true
+	case *CreateUserStatement, *AlterUserStatement, *DropUserStatement,
+		*CreateRoleStatement, *DropRoleStatement,
+		*GrantStatement, *GrantRoleStatement, *RevokeStatement, *RevokeAllStatement, *RevokeRoleStatement,
+		*SetDefaultRoleStatement, *SetPasswordStatement:
+		return true
+	case *StartTransactionStatement, *BeginStatement, *LockTablesStatement, *UnlockTablesStatement:
+		return true
+	case *AnalyzeTableStatement, *OptimizeTableStatement, *CheckTableStatement, *FlushStatement, *FlushTablesStatement:
+		return true
+	case *SetStatement:
+		for _, assignment := range x.Assignments {
+			if isEnablingAutocommit(assignment) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ContainsImplicitCommit returns true if any of statements causes implicit commit.
+func ContainsImplicitCommit(statements []Statement) bool {
+	for _, stmt := range statements {
+		if CausesImplicitCommit(stmt) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEnablingAutocommit(assignment SetAssignment) bool {
+	x, ok := assignment.(*SetAssignmentVariable)
+	if !ok {
+		return false
+	}
+	variable, ok := x.Variable.(*SystemVariableExpression)
+	if !ok || strings.ToLower(variable.Name) != "autocommit" {
+		return false
+	}
+	switch value := x.Value.(type) {
+	case *NumberExpression:
+		return value.Value != "0"
+	case *BoolExpression:
+		return value.Value
+	case *ColumnExpression:
+		// ON is parsed as a column
+		return strings.ToUpper(value.ColumnName.Name) == "ON"
+	}
+	return false
+}
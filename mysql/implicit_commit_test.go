@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"testing"
+)
+
+func TestCausesImplicitCommit(t *testing.T) {
+	for _, c := range []struct {
+		src    string
+		expect bool
+	}{
+		{"CREATE TABLE hoge ( id INT );", true},
+		{"ALTER TABLE hoge ADD COLUMN fuga INT;", true},
+		{"DROP DATABASE hoge;", true},
+		{"GRANT SELECT ON *.* TO app;", true},
+		{"START TRANSACTION;", true},
+		{"LOCK TABLES hoge WRITE;", true},
+		{"ANALYZE TABLE hoge;", true},
+		{"/*!40000 FLUSH TABLES */;", true},
+		{"SET autocommit = 1;", true},
+		{"SET @@SESSION.autocommit = ON;", true},
+		{"SET autocommit = TRUE;", true},
+		{"SET autocommit = 0;", false},
+		{"SET autocommit = FALSE;", false},
+		{"SET NAMES utf8mb4;", false},
+		{"COMMIT;", false},
+		{"ROLLBACK TO SAVEPOINT sp1;", false},
+		{"SAVEPOINT sp1;", false},
+		{"/* hoge */;", false},
+	} {
+		s := new(Scanner)
+		s.Init(c.src)
+		statements, err := Parse(s)
+		if err != nil {
+			t.Errorf("Parse failed %s", err)
+			continue
+		}
+		if got := CausesImplicitCommit(statements[0]); got != c.expect {
+			t.Errorf("Expect CausesImplicitCommit(%q) to be %v, but got %v", c.src, c.expect, got)
+		}
+	}
+}
+
+func TestContainsImplicitCommit(t *testing.T) {
+	s := new(Scanner)
+	s.Init("START TRANSACTION; SET @a = 1; COMMIT;")
+	statements, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse failed %s", err)
+	}
+	if !ContainsImplicitCommit(statements) {
+		t.Errorf("Expect START TRANSACTION to cause implicit commit")
+	}
+	if ContainsImplicitCommit(statements[1:]) {
+		t.Errorf("Expect SET and COMMIT not to cause implicit commit")
+	}
+}
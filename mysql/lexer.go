@@ -4,7 +4,10 @@ package mysql
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 const (
@@ -13,39 +16,113 @@ const (
 )
 
 var keywords = map[string]int{
-	"ADD":               ADD,
-	"DROP":              DROP,
-	"CREATE":            CREATE,
-	"ALTER":             ALTER,
-	"COLUMN":            COLUMN,
-	"TABLE":             TABLE,
-	"INDEX":             INDEX,
-	"KEY":               KEY,
-	"DATABASE":          DATABASE,
-	"NULL":              NULL,
-	"NOT":               NOT,
-	"AUTO_INCREMENT":    AUTO_INCREMENT,
-	"DEFAULT":           DEFAULT,
-	"CURRENT_TIMESTAMP": CURRENT_TIMESTAMP,
-	"ON":                ON,
-	"UPDATE":            UPDATE,
-	"PRIMARY":           PRIMARY,
-	"UNIQUE":            UNIQUE,
-	"USING":             USING,
-	"HASH":              HASH,
-	"BTREE":             BTREE,
-	"ENGINE":            ENGINE,
-	"CHARSET":           CHARSET,
-	"CHARACTER":         CHARACTER,
-	"COLLATE":           COLLATE,
-	"SET":               SET,
-	"AVG_ROW_LENGTH":    AVG_ROW_LENGTH,
-	"CHECKSUM":          CHECKSUM,
-	"COMMENT":           COMMENT,
-	"KEY_BLOCK_SIZE":    KEY_BLOCK_SIZE,
-	"MAX_ROWS":          MAX_ROWS,
-	"MIN_ROWS":          MIN_ROWS,
-	"ROW_FORMAT":        ROW_FORMAT,
+	"ADD":                        ADD,
+	"DROP":                       DROP,
+	"CREATE":                     CREATE,
+	"ALTER":                      ALTER,
+	"COLUMN":                     COLUMN,
+	"TABLE":                      TABLE,
+	"INDEX":                      INDEX,
+	"KEY":                        KEY,
+	"DATABASE":                   DATABASE,
+	"NULL":                       NULL,
+	"NOT":                        NOT,
+	"AUTO_INCREMENT":             AUTO_INCREMENT,
+	"DEFAULT":                    DEFAULT,
+	"CURRENT_TIMESTAMP":          CURRENT_TIMESTAMP,
+	"ON":                         ON,
+	"UPDATE":                     UPDATE,
+	"PRIMARY":                    PRIMARY,
+	"UNIQUE":                     UNIQUE,
+	"USING":                      USING,
+	"HASH":                       HASH,
+	"BTREE":                      BTREE,
+	"ENGINE":                     ENGINE,
+	"CHARSET":                    CHARSET,
+	"CHARACTER":                  CHARACTER,
+	"COLLATE":                    COLLATE,
+	"SET":                        SET,
+	"AVG_ROW_LENGTH":             AVG_ROW_LENGTH,
+	"CHECKSUM":                   CHECKSUM,
+	"COMMENT":                    COMMENT,
+	"KEY_BLOCK_SIZE":             KEY_BLOCK_SIZE,
+	"MAX_ROWS":                   MAX_ROWS,
+	"MIN_ROWS":                   MIN_ROWS,
+	"ROW_FORMAT":                 ROW_FORMAT,
+	"DYNAMIC":                    DYNAMIC,
+	"FIXED":                      FIXED,
+	"COMPRESSED":                 COMPRESSED,
+	"REDUNDANT":                  REDUNDANT,
+	"COMPACT":                    COMPACT,
+	"AUTOEXTEND_SIZE":            AUTOEXTEND_SIZE,
+	"COMPRESSION":                COMPRESSION,
+	"CONNECTION":                 CONNECTION,
+	"DELAY_KEY_WRITE":            DELAY_KEY_WRITE,
+	"ENCRYPTION":                 ENCRYPTION,
+	"ENGINE_ATTRIBUTE":           ENGINE_ATTRIBUTE,
+	"INSERT_METHOD":              INSERT_METHOD,
+	"PACK_KEYS":                  PACK_KEYS,
+	"PASSWORD":                   PASSWORD,
+	"SECONDARY_ENGINE":           SECONDARY_ENGINE,
+	"SECONDARY_ENGINE_ATTRIBUTE": SECONDARY_ENGINE_ATTRIBUTE,
+	"STATS_AUTO_RECALC":          STATS_AUTO_RECALC,
+	"STATS_PERSISTENT":           STATS_PERSISTENT,
+	"STATS_SAMPLE_PAGES":         STATS_SAMPLE_PAGES,
+	"UNION":                      UNION,
+	"DISK":                       DISK,
+	"MEMORY":                     MEMORY,
+	"NO":                         NO,
+	"FIRST":                      FIRST,
+	"LAST":                       LAST,
+	"DATA":                       DATA,
+	"DIRECTORY":                  DIRECTORY,
+	"TABLESPACE":                 TABLESPACE,
+	"STORAGE":                    STORAGE,
+	"NODEGROUP":                  NODEGROUP,
+	"IN":                         IN,
+	"ALL":                        ALL,
+	"INTO":                       INTO,
+	"WITH":                       WITH,
+	"WITHOUT":                    WITHOUT,
+	"VALIDATION":                 VALIDATION,
+	"DIV":                        DIV,
+	"MOD":                        MOD,
+
+	// variables
+	"NAMES":        NAMES,
+	"GLOBAL":       GLOBAL,
+	"SESSION":      SESSION,
+	"LOCAL":        LOCAL,
+	"PERSIST":      PERSIST,
+	"PERSIST_ONLY": PERSIST_ONLY,
+
+	// partitioning
+	"PARTITION":     PARTITION,
+	"PARTITIONS":    PARTITIONS,
+	"SUBPARTITION":  SUBPARTITION,
+	"SUBPARTITIONS": SUBPARTITIONS,
+	"PARTITIONING":  PARTITIONING,
+	"BY":            BY,
+	"LINEAR":        LINEAR,
+	"ALGORITHM":     ALGORITHM,
+	"RANGE":         RANGE,
+	"LIST":          LIST,
+	"COLUMNS":       COLUMNS,
+	"VALUES":        VALUES,
+	"LESS":          LESS,
+	"THAN":          THAN,
+	"MAXVALUE":      MAXVALUE,
+	"REORGANIZE":    REORGANIZE,
+	"TRUNCATE":      TRUNCATE,
+	"EXCHANGE":      EXCHANGE,
+	"COALESCE":      COALESCE,
+	"REMOVE":        REMOVE,
+	"ENABLE":        ENABLE,
+	"DISABLE":       DISABLE,
+	"GENERATED":     GENERATED,
+	"ALWAYS":        ALWAYS,
+	"VIRTUAL":       VIRTUAL,
+	"STORED":        STORED,
 
 	// datatypes
 	"BIT":        BIT,
@@ -81,219 +158,1151 @@ var keywords = map[string]int{
 	// datatype options
 	"UNSIGNED": UNSIGNED,
 	"ZEROFILL": ZEROFILL,
+
+	// accounts
+	"USER":         USER,
+	"ROLE":         ROLE,
+	"GRANT":        GRANT,
+	"REVOKE":       REVOKE,
+	"IDENTIFIED":   IDENTIFIED,
+	"RANDOM":       RANDOM,
+	"AS":           AS,
+	"TO":           TO,
+	"FROM":         FROM,
+	"FOR":          FOR,
+	"OPTION":       OPTION,
+	"ADMIN":        ADMIN,
+	"PRIVILEGES":   PRIVILEGES,
+	"IF":           IF,
+	"EXISTS":       EXISTS,
+	"ACCOUNT":      ACCOUNT,
+	"LOCK":         LOCK,
+	"UNLOCK":       UNLOCK,
+	"NONE":         NONE,
+	"CURRENT_USER": CURRENT_USER,
+	"FUNCTION":     FUNCTION,
+	"PROCEDURE":    PROCEDURE,
+	"ROUTINE":      ROUTINE,
+	"TEMPORARY":    TEMPORARY,
+	"TABLES":       TABLES,
+	"VIEW":         VIEW,
+	"SHOW":         SHOW,
+	"DATABASES":    DATABASES,
+	"SELECT":       SELECT,
+	"INSERT":       INSERT,
+	"DELETE":       DELETE,
+	"REFERENCES":   REFERENCES,
+	"REPLICATION":  REPLICATION,
+	"CLIENT":       CLIENT,
+	"SLAVE":        SLAVE,
+	"TRIGGER":      TRIGGER,
+	"USAGE":        USAGE,
+
+	// tablespaces and servers
+	"UNDO":             UNDO,
+	"DATAFILE":         DATAFILE,
+	"UNDOFILE":         UNDOFILE,
+	"LOGFILE":          LOGFILE,
+	"GROUP":            GROUP,
+	"USE":              USE,
+	"FILE_BLOCK_SIZE":  FILE_BLOCK_SIZE,
+	"EXTENT_SIZE":      EXTENT_SIZE,
+	"INITIAL_SIZE":     INITIAL_SIZE,
+	"MAX_SIZE":         MAX_SIZE,
+	"UNDO_BUFFER_SIZE": UNDO_BUFFER_SIZE,
+	"REDO_BUFFER_SIZE": REDO_BUFFER_SIZE,
+	"WAIT":             WAIT,
+	"NO_WAIT":          NO_WAIT,
+	"RENAME":           RENAME,
+	"ACTIVE":           ACTIVE,
+	"INACTIVE":         INACTIVE,
+	"SERVER":           SERVER,
+	"FOREIGN":          FOREIGN,
+	"WRAPPER":          WRAPPER,
+	"OPTIONS":          OPTIONS,
+	"HOST":             HOST,
+	"SOCKET":           SOCKET,
+	"OWNER":            OWNER,
+	"PORT":             PORT,
+
+	// transactions and administration
+	"START":              START,
+	"TRANSACTION":        TRANSACTION,
+	"BEGIN":              BEGIN,
+	"WORK":               WORK,
+	"COMMIT":             COMMIT,
+	"ROLLBACK":           ROLLBACK,
+	"SAVEPOINT":          SAVEPOINT,
+	"RELEASE":            RELEASE,
+	"CHAIN":              CHAIN,
+	"CONSISTENT":         CONSISTENT,
+	"SNAPSHOT":           SNAPSHOT,
+	"READ":               READ,
+	"WRITE":              WRITE,
+	"ONLY":               ONLY,
+	"AND":                AND,
+	"LOW_PRIORITY":       LOW_PRIORITY,
+	"ANALYZE":            ANALYZE,
+	"OPTIMIZE":           OPTIMIZE,
+	"CHECK":              CHECK,
+	"NO_WRITE_TO_BINLOG": NO_WRITE_TO_BINLOG,
+	"QUICK":              QUICK,
+	"FAST":               FAST,
+	"MEDIUM":             MEDIUM,
+	"EXTENDED":           EXTENDED,
+	"CHANGED":            CHANGED,
+	"UPGRADE":            UPGRADE,
+	"FLUSH":              FLUSH,
+	"LOGS":               LOGS,
+	"ERROR":              ERROR,
+	"GENERAL":            GENERAL,
+	"HOSTS":              HOSTS,
+	"OPTIMIZER_COSTS":    OPTIMIZER_COSTS,
+	"RELAY":              RELAY,
+	"SLOW":               SLOW,
+	"STATUS":             STATUS,
+	"USER_RESOURCES":     USER_RESOURCES,
+	"EXPORT":             EXPORT,
+
+	// data manipulation
+	"DISTINCT":      DISTINCT,
+	"WHERE":         WHERE,
+	"HAVING":        HAVING,
+	"ORDER":         ORDER,
+	"ASC":           ASC,
+	"DESC":          DESC,
+	"LIMIT":         LIMIT,
+	"OFFSET":        OFFSET,
+	"JOIN":          JOIN,
+	"INNER":         INNER,
+	"CROSS":         CROSS,
+	"LEFT":          LEFT,
+	"RIGHT":         RIGHT,
+	"OUTER":         OUTER,
+	"NATURAL":       NATURAL,
+	"STRAIGHT_JOIN": STRAIGHT_JOIN,
+	"DUAL":          DUAL,
+	"SHARE":         SHARE,
+	"MODE":          MODE,
+	"IGNORE":        IGNORE,
+	"VALUE":         VALUE,
+	"DUPLICATE":     DUPLICATE,
+	"REPLACE":       REPLACE,
+	"DELAYED":       DELAYED,
+	"HIGH_PRIORITY": HIGH_PRIORITY,
+	"OR":            OR,
+	"XOR":           XOR,
+	"IS":            IS,
+	"LIKE":          LIKE,
+	"ESCAPE":        ESCAPE,
+	"BETWEEN":       BETWEEN,
+	"TRUE":          TRUE,
+	"FALSE":         FALSE,
+	"UNKNOWN":       UNKNOWN_SYM,
+	"PREPARE":       PREPARE,
+	"EXECUTE":       EXECUTE,
+	"DEALLOCATE":    DEALLOCATE,
+
+	// show and explain
+	"EXPLAIN":     EXPLAIN,
+	"DESCRIBE":    DESCRIBE,
+	"FORMAT":      FORMAT,
+	"FULL":        FULL,
+	"FIELDS":      FIELDS,
+	"INDEXES":     INDEXES,
+	"KEYS":        KEYS,
+	"VARIABLES":   VARIABLES,
+	"PROCESSLIST": PROCESSLIST,
+	"WARNINGS":    WARNINGS,
+	"ERRORS":      ERRORS,
+	"GRANTS":      GRANTS,
+
+	// query expressions
+	"OVER":       OVER,
+	"ROWS":       ROWS,
+	"PRECEDING":  PRECEDING,
+	"FOLLOWING":  FOLLOWING,
+	"UNBOUNDED":  UNBOUNDED,
+	"CURRENT":    CURRENT,
+	"ROW":        ROW,
+	"WINDOW":     WINDOW,
+	"RECURSIVE":  RECURSIVE,
+	"INTERSECT":  INTERSECT,
+	"EXCEPT":     EXCEPT,
+	"LATERAL":    LATERAL,
+	"JSON_TABLE": JSON_TABLE,
+	"PATH":       PATH,
+	"ORDINALITY": ORDINALITY,
+	"NESTED":     NESTED,
+	"SKIP":       SKIP,
+	"LOCKED":     LOCKED,
+	"NOWAIT":     NOWAIT,
+	"OF":         OF,
+	"EMPTY":      EMPTY,
 }
 
+// Position is a location in the source. Line and Column are counted from
+// 1 in characters, and Offset is the byte offset from the head of the
+// source.
 type Position struct {
 	Line   int
 	Column int
+	Offset int
+}
+
+// Comment is a comment which was skipped by Scanner. Text contains the
+// delimiters of comment such as "-- ", "#" or "/* */".
+type Comment struct {
+	Text string
+	Pos  Position
+}
+
+// keywords that may be directly followed by an optimizer hint comment.
+var optimizerHintKeywords = map[string]bool{
+	"SELECT":  true,
+	"INSERT":  true,
+	"REPLACE": true,
+	"UPDATE":  true,
+	"DELETE":  true,
+}
+
+// reservedKeywords are the reserved words of MySQL 8.0. They can be used as
+// identifiers only when they are quoted. Reserved words which have no token
+// of their own are scanned as RESERVED_KEYWORD.
+var reservedKeywords = map[string]bool{
+	"ACCESSIBLE": true, "ADD": true, "ALL": true, "ALTER": true, "ANALYZE": true, "AND": true,
+	"AS": true, "ASC": true, "ASENSITIVE": true, "BEFORE": true, "BETWEEN": true, "BIGINT": true,
+	"BINARY": true, "BLOB": true, "BOTH": true, "BY": true, "CALL": true, "CASCADE": true,
+	"CASE": true, "CHANGE": true, "CHAR": true, "CHARACTER": true, "CHECK": true, "COLLATE": true,
+	"COLUMN": true, "CONDITION": true, "CONSTRAINT": true, "CONTINUE": true, "CONVERT": true,
+	"CREATE": true, "CROSS": true, "CUBE": true, "CUME_DIST": true, "CURRENT_DATE": true,
+	"CURRENT_TIME": true, "CURRENT_TIMESTAMP": true, "CURRENT_USER": true, "CURSOR": true,
+	"DATABASE": true, "DATABASES": true, "DAY_HOUR": true, "DAY_MICROSECOND": true,
+	"DAY_MINUTE": true, "DAY_SECOND": true, "DEC": true, "DECIMAL": true, "DECLARE": true,
+	"DEFAULT": true, "DELAYED": true, "DELETE": true, "DENSE_RANK": true, "DESC": true,
+	"DESCRIBE": true, "DETERMINISTIC": true, "DISTINCT": true, "DISTINCTROW": true, "DIV": true,
+	"DOUBLE": true, "DROP": true, "DUAL": true, "EACH": true, "ELSE": true, "ELSEIF": true,
+	"EMPTY": true, "ENCLOSED": true, "ESCAPED": true, "EXCEPT": true, "EXISTS": true, "EXIT": true,
+	"EXPLAIN": true, "FALSE": true, "FETCH": true, "FIRST_VALUE": true, "FLOAT": true, "FLOAT4": true,
+	"FLOAT8": true, "FOR": true, "FORCE": true, "FOREIGN": true, "FROM": true, "FULLTEXT": true,
+	"FUNCTION": true, "GENERATED": true, "GET": true, "GRANT": true, "GROUP": true, "GROUPING": true,
+	"GROUPS": true, "HAVING": true, "HIGH_PRIORITY": true, "HOUR_MICROSECOND": true,
+	"HOUR_MINUTE": true, "HOUR_SECOND": true, "IF": true, "IGNORE": true, "IN": true, "INDEX": true,
+	"INFILE": true, "INNER": true, "INOUT": true, "INSENSITIVE": true, "INSERT": true, "INT": true,
+	"INT1": true, "INT2": true, "INT3": true, "INT4": true, "INT8": true, "INTEGER": true,
+	"INTERSECT": true, "INTERVAL": true, "INTO": true, "IO_AFTER_GTIDS": true,
+	"IO_BEFORE_GTIDS": true, "IS": true, "ITERATE": true, "JOIN": true, "JSON_TABLE": true,
+	"KEY": true, "KEYS": true, "KILL": true, "LAG": true, "LAST_VALUE": true, "LATERAL": true,
+	"LEAD": true, "LEADING": true, "LEAVE": true, "LEFT": true, "LIKE": true, "LIMIT": true,
+	"LINEAR": true, "LINES": true, "LOAD": true, "LOCALTIME": true, "LOCALTIMESTAMP": true,
+	"LOCK": true, "LONG": true, "LONGBLOB": true, "LONGTEXT": true, "LOOP": true,
+	"LOW_PRIORITY": true, "MASTER_BIND": true, "MASTER_SSL_VERIFY_SERVER_CERT": true, "MATCH": true,
+	"MAXVALUE": true, "MEDIUMBLOB": true, "MEDIUMINT": true, "MEDIUMTEXT": true, "MIDDLEINT": true,
+	"MINUTE_MICROSECOND": true, "MINUTE_SECOND": true, "MOD": true, "MODIFIES": true, "NATURAL": true,
+	"NOT": true, "NO_WRITE_TO_BINLOG": true, "NTH_VALUE": true, "NTILE": true, "NULL": true,
+	"NUMERIC": true, "OF": true, "ON": true, "OPTIMIZE": true, "OPTIMIZER_COSTS": true,
+	"OPTION": true, "OPTIONALLY": true, "OR": true, "ORDER": true, "OUT": true, "OUTER": true,
+	"OUTFILE": true, "OVER": true, "PARTITION": true, "PERCENT_RANK": true, "PRECISION": true,
+	"PRIMARY": true, "PROCEDURE": true, "PURGE": true, "RANGE": true, "RANK": true, "READ": true,
+	"READS": true, "READ_WRITE": true, "REAL": true, "RECURSIVE": true, "REFERENCES": true,
+	"REGEXP": true, "RELEASE": true, "RENAME": true, "REPEAT": true, "REPLACE": true, "REQUIRE": true,
+	"RESIGNAL": true, "RESTRICT": true, "RETURN": true, "REVOKE": true, "RIGHT": true, "RLIKE": true,
+	"ROW": true, "ROWS": true, "ROW_NUMBER": true, "SCHEMA": true, "SCHEMAS": true,
+	"SECOND_MICROSECOND": true, "SELECT": true, "SENSITIVE": true, "SEPARATOR": true, "SET": true,
+	"SHOW": true, "SIGNAL": true, "SMALLINT": true, "SPATIAL": true, "SPECIFIC": true, "SQL": true,
+	"SQLEXCEPTION": true, "SQLSTATE": true, "SQLWARNING": true, "SQL_BIG_RESULT": true,
+	"SQL_CALC_FOUND_ROWS": true, "SQL_SMALL_RESULT": true, "SSL": true, "STARTING": true,
+	"STORED": true, "STRAIGHT_JOIN": true, "SYSTEM": true, "TABLE": true, "TERMINATED": true,
+	"THEN": true, "TINYBLOB": true, "TINYINT": true, "TINYTEXT": true, "TO": true, "TRAILING": true,
+	"TRIGGER": true, "TRUE": true, "UNDO": true, "UNION": true, "UNIQUE": true, "UNLOCK": true,
+	"UNSIGNED": true, "UPDATE": true, "USAGE": true, "USE": true, "USING": true, "UTC_DATE": true,
+	"UTC_TIME": true, "UTC_TIMESTAMP": true, "VALUES": true, "VARBINARY": true, "VARCHAR": true,
+	"VARCHARACTER": true, "VARYING": true, "VIRTUAL": true, "WHEN": true, "WHERE": true,
+	"WHILE": true, "WINDOW": true, "WITH": true, "WRITE": true, "XOR": true, "YEAR_MONTH": true,
+	"ZEROFILL": true,
+}
+
+// reservedSinceMySQL80 are the words which have been reserved since MySQL
+// 8.0 and have no token of their own. They are identifiers for MySQL 5.7
+// and MariaDB.
+var reservedSinceMySQL80 = map[string]bool{
+	"CUBE": true, "CUME_DIST": true, "DENSE_RANK": true, "FIRST_VALUE": true, "GROUPING": true,
+	"LAG": true, "LAST_VALUE": true, "LEAD": true, "NTH_VALUE": true, "NTILE": true,
+	"PERCENT_RANK": true, "RANK": true, "ROW_NUMBER": true, "SYSTEM": true,
+}
+
+// ignoreSpaceFunctions are the built-in functions whose names are affected
+// by the IGNORE_SPACE SQL mode.
+var ignoreSpaceFunctions = map[string]bool{
+	"ADDDATE": true, "BIT_AND": true, "BIT_OR": true, "BIT_XOR": true, "CAST": true, "COUNT": true,
+	"CURDATE": true, "CURTIME": true, "DATE_ADD": true, "DATE_SUB": true, "EXTRACT": true,
+	"GROUP_CONCAT": true, "MAX": true, "MID": true, "MIN": true, "NOW": true, "POSITION": true,
+	"SESSION_USER": true, "STD": true, "STDDEV": true, "STDDEV_POP": true, "STDDEV_SAMP": true,
+	"SUBDATE": true, "SUBSTR": true, "SUBSTRING": true, "SUM": true, "SYSDATE": true,
+	"SYSTEM_USER": true, "TRIM": true, "VARIANCE": true, "VAR_POP": true, "VAR_SAMP": true,
+}
+
+// IsReservedKeyword returns true if word is a reserved word of MySQL, which
+// must be quoted to be used as an identifier.
+func IsReservedKeyword(word string) bool {
+	return reservedKeywords[strings.ToUpper(word)]
 }
 
+// Scanner scans tokens of SQL. Offsets in the source are counted in bytes.
 type Scanner struct {
-	src          []rune
-	offset       int
-	lineHead     int
-	line         int
-	markRawUntil []rune
+	src    string
+	offset int
+	// the offset of src in the whole source, which is not zero when src is
+	// a part of the source read by StatementReader.
+	baseOffset int
+	// line and column of the current offset counted from 0.
+	line   int
+	column int
+	// the closing quote of the current RAW
+	markRawUntil string
 	nextLiteral  string
+
+	// ServerVersion is the version of the target server in the form of
+	// executable comments (e.g. 50709 for 5.7.9). The content of
+	// /*!NNNNN ... */ is scanned as SQL only when NNNNN <= ServerVersion.
+	// Zero means that all executable comments are scanned as SQL.
+	ServerVersion uint
+
+	// If KeepComments is true, comments skipped between tokens are
+	// stored into Comments.
+	KeepComments bool
+	Comments     []Comment
+
+	// If ANSIQuotes is true, "..." is scanned as a quoted identifier
+	// instead of a string, like the ANSI_QUOTES SQL mode.
+	ANSIQuotes bool
+
+	// If NoBackslashEscapes is true, backslash is an ordinary character
+	// in strings, like the NO_BACKSLASH_ESCAPES SQL mode.
+	NoBackslashEscapes bool
+
+	// If PipesAsConcat is true, || concatenates strings instead of OR,
+	// like the PIPES_AS_CONCAT SQL mode.
+	PipesAsConcat bool
+
+	// If HighNotPrecedence is true, NOT has higher precedence than
+	// comparison operators, like the HIGH_NOT_PRECEDENCE SQL mode.
+	// e.g. NOT a BETWEEN b AND c means (NOT a) BETWEEN b AND c.
+	HighNotPrecedence bool
+
+	// If IgnoreSpace is true, spaces are allowed between the name of a
+	// built-in function such as COUNT and '(', and the names are reserved
+	// words like the IGNORE_SPACE SQL mode. Otherwise the names followed
+	// by spaces are not function calls.
+	IgnoreSpace bool
+
+	// If MariaDB is true, /*M!NNNNNN ... */ is an executable comment and
+	// the words reserved only by MySQL 8.0 such as RANK are identifiers.
+	MariaDB bool
+
+	midStatement bool
+	lastLit      string
+	// offset of the most recent token
+	tokenOffset int
+	// the end of the source to scan
+	end int
+	// statement delimiter changed by DELIMITER command. Empty means ';'.
+	delimiter string
+	// true if the delimiter missing at the end of the source is supplied,
+	// so that the last statement of a range is terminated.
+	terminateAtEnd bool
+	// the position and offset of the opening quote of the current RAW
+	quotePos    Position
+	quoteOffset int
+	// the reason of the most recent ILLEGAL token
+	illegalReason string
+	// true while NextToken is scanning a token, so that the skipped
+	// comments are kept in pendingTokens.
+	tokenizing    bool
+	pendingTokens []Token
+
+	// true while scanning the inside of /*!NNNNN ... */
+	inVersionComment bool
+	// true if the current executable comment encloses a whole statement.
+	versionCommentTokens bool
+	// version and position of the executable comment inside a statement
+	// which encloses the current token.
+	versionGate       uint
+	versionCommentPos Position
+	// true while scanning the inside of /*M!NNNNN ... */ for MariaDB.
+	mariaDBComment bool
 }
 
 func (s *Scanner) Init(src string) {
-	s.src = []rune(src)
+	s.src = src
+	s.end = len(s.src)
 }
 
 func (s *Scanner) Scan() (tok int, lit string, pos Position) {
+	tok, lit, pos = s.scan()
+	if tok == EOF && s.terminateAtEnd && s.midStatement {
+		tok = ';'
+	}
+	if tok != EOF {
+		s.midStatement = tok != ';'
+	}
+	s.lastLit = lit
+	return
+}
+
+func (s *Scanner) scan() (tok int, lit string, pos Position) {
 	if s.nextLiteral != "" {
 		switch s.nextLiteral {
-		case "*/":
-			tok = COMMENT_FINISH
 		case "`":
 			tok = int('`')
 		case "'":
 			tok = int('\'')
 		case "\"":
 			tok = int('"')
+			if s.ANSIQuotes {
+				tok = int('`')
+			}
 		}
 		pos = s.position()
-		for i := 0; i < len(s.nextLiteral); i++ {
-			s.next()
-		}
+		s.tokenOffset = s.offset
+		s.scanBytes(len(s.nextLiteral))
 		lit = s.nextLiteral
 		s.nextLiteral = ""
 		return
 	}
-	if len(s.markRawUntil) == 0 {
+	if s.markRawUntil == "" {
 		s.skipWhiteSpace()
 		pos = s.position()
+		s.tokenOffset = s.offset
 		switch ch := s.peek(); {
-		case ch == '/' && s.readAhead(1) == '*':
+		case s.delimiter != "" && s.hasPrefix(s.delimiter):
+			lit = s.scanBytes(len(s.delimiter))
+			tok = ';'
+		case ch == ';' && s.delimiter != "":
+			// ';' doesn't terminate the statement such as BEGIN ... END
+			// after the delimiter is changed.
+			s.next()
+			lit = ";"
+			tok = INNER_SEMICOLON
+		case s.executableCommentPrefix() > 0:
+			prefix := s.executableCommentPrefix()
+			s.scanBytes(prefix)
+			lit = s.scanNumber()
+			s.inVersionComment = true
+			s.mariaDBComment = prefix == len("/*M!")
+			if s.midStatement {
+				// the content is a part of the current statement,
+				// so that the comment itself is invisible to the parser.
+				version, err := strconv.Atoi(lit)
+				if err != nil {
+					version = 0
+				}
+				s.versionGate = uint(version)
+				s.versionCommentPos = pos
+				s.skipToken(TOKEN_KIND_EXECUTABLE_COMMENT, lit, s.tokenOffset, pos)
+				return s.scan()
+			}
+			s.versionCommentTokens = true
+			tok = VERSION_COMMENT_START
+		case ch == '*' && s.readAhead(1) == '/' && s.inVersionComment:
+			s.next()
+			s.next()
+			s.inVersionComment = false
+			s.mariaDBComment = false
+			if !s.versionCommentTokens {
+				s.versionGate = 0
+				s.skipToken(TOKEN_KIND_EXECUTABLE_COMMENT, "*/", s.tokenOffset, pos)
+				return s.scan()
+			}
+			s.versionCommentTokens = false
+			tok = COMMENT_FINISH
+			lit = "*/"
+		case ch == '/' && s.readAhead(1) == '*' && s.readAhead(2) == '+' && optimizerHintKeywords[strings.ToUpper(s.lastLit)]:
+			s.next()
+			s.next()
+			s.next()
+			var err error
+			lit, err = s.scanUntil("*/")
+			if err != nil {
+				lit = "/*+" + lit
+				tok = s.illegal(err.Error())
+				break
+			}
 			s.next()
 			s.next()
-			tok = COMMENT_START
-			lit = "/*"
-			s.markRawUntil = []rune{'*', '/'}
+			tok = OPTIMIZER_HINT
+		case s.commentLength() > 0 && s.isUnterminatedComment(s.commentLength()):
+			lit = s.scanBytes(s.commentLength())
+			tok = s.illegal("unterminated comment")
+		case s.commentLength() > 0:
+			// a comment which is followed by ';' is a statement.
+			lit = s.scanComment()
+			tok = COMMENT_TEXT
 		case isLetter(ch):
 			lit = s.scanIdentifier()
-			if keyword, ok := keywords[strings.ToUpper(lit)]; ok {
+			var buf [maxKeywordLength]byte
+			word := upperKeyword(buf[:0], lit)
+			if (string(word) == "X" || string(word) == "B") && s.peek() == '\'' {
+				s.scanPrefixedLiteral()
+				lit = s.src[s.tokenOffset:s.offset]
+				tok = HEX_STRING
+				if string(word) == "B" {
+					tok = BIT_STRING
+				}
+			} else if string(word) == "N" && s.isStringQuote(s.peek()) {
+				tok = NATIONAL_INTRODUCER
+			} else if len(lit) > 1 && lit[0] == '_' && s.isStringQuote(s.peek()) {
+				tok = UNDERSCORE_CHARSET
+			} else if keyword, ok := keywords[string(word)]; ok {
 				tok = keyword
+			} else if reservedKeywords[string(word)] && !s.isUnreservedWord(word) {
+				tok = RESERVED_KEYWORD
+			} else if s.IgnoreSpace && ignoreSpaceFunctions[string(word)] {
+				tok = RESERVED_KEYWORD
 			} else {
 				tok = IDENT
 			}
-		case isNumber(ch):
-			lit = s.scanNumber()
-			tok = NUMBER
+		case isNumber(ch), ch == '.' && isNumber(s.readAhead(1)) && !s.isQualifier():
+			tok, lit = s.scanNumeric()
 		case ch == '`':
-			s.markRawUntil = []rune{'`'}
+			s.markRawUntil = s.src[s.offset : s.offset+1]
+			s.quotePos, s.quoteOffset = pos, s.offset
 			tok = int(ch)
-			lit = string(ch)
 			s.next()
+			lit = s.src[s.tokenOffset:s.offset]
 		case ch == '\'':
-			s.markRawUntil = []rune{'\''}
+			s.markRawUntil = s.src[s.offset : s.offset+1]
+			s.quotePos, s.quoteOffset = pos, s.offset
 			tok = int(ch)
-			lit = string(ch)
 			s.next()
+			lit = s.src[s.tokenOffset:s.offset]
 		case ch == '"':
-			s.markRawUntil = []rune{'"'}
+			s.markRawUntil = s.src[s.offset : s.offset+1]
+			s.quotePos, s.quoteOffset = pos, s.offset
+			tok = int(ch)
+			if s.ANSIQuotes {
+				tok = int('`')
+			}
+			s.next()
+			lit = s.src[s.tokenOffset:s.offset]
+		case ch == '|' && s.readAhead(1) == '|':
+			s.next()
+			s.next()
+			lit = "||"
+			tok = OR
+			if s.PipesAsConcat {
+				tok = CONCAT_PIPES
+			}
+		case ch == '@' && s.readAhead(1) == '@':
+			s.next()
+			s.next()
+			lit = s.scanVariableName()
+			tok = SYSTEM_VARIABLE
+		case ch == '<' && s.readAhead(1) == '=' && s.readAhead(2) == '>':
+			s.next()
+			s.next()
+			s.next()
+			tok = NULL_SAFE_EQUAL
+			lit = "<=>"
+		case ch == '<' && s.readAhead(1) == '=':
+			s.next()
+			s.next()
+			tok = LE
+			lit = "<="
+		case ch == '>' && s.readAhead(1) == '=':
+			s.next()
+			s.next()
+			tok = GE
+			lit = ">="
+		case ch == '<' && s.readAhead(1) == '>', ch == '!' && s.readAhead(1) == '=':
+			s.next()
+			s.next()
+			lit = s.src[s.tokenOffset:s.offset]
+			tok = NE
+		case ch == '@' && (s.readAhead(1) == '\'' || s.readAhead(1) == '"' || s.readAhead(1) == '`'):
+			// host part of account name such as 'user'@'localhost'
 			tok = int(ch)
-			lit = string(ch)
 			s.next()
+			lit = s.src[s.tokenOffset:s.offset]
+		case ch == '@':
+			s.next()
+			lit = s.scanIdentifier()
+			tok = USER_VARIABLE
 		default:
 			switch ch {
 			case -1:
 				tok = EOF
-			case ';', ',', '`', '.', '(', ')', '=':
+			case ';', ',', '`', '.', '(', ')', '=', '+', '-', '*', '/', '%', '?', '<', '>', '!':
 				tok = int(ch)
-				lit = string(ch)
+			default:
+				tok = s.illegal(fmt.Sprintf("unexpected character %q", ch))
 			}
 			s.next()
+			lit = s.src[s.tokenOffset:s.offset]
 		}
 	} else {
+		pos = s.position()
+		s.tokenOffset = s.offset
 		var err error
-		lit, err = s.scanUntil(s.markRawUntil)
+		quote := s.markRawUntil
+		if s.isIdentifierQuote(quote) {
+			lit, err = s.scanQuotedIdentifier(rune(quote[0]))
+		} else if len(quote) == 1 && s.isStringQuote(rune(quote[0])) {
+			lit, err = s.scanQuotedString(rune(quote[0]))
+		} else {
+			lit, err = s.scanUntil(quote)
+		}
+		s.markRawUntil = ""
 		if err != nil {
-			panic(err)
+			// the error is reported at the opening quote, which has
+			// already been returned.
+			s.tokenOffset = s.quoteOffset
+			return s.illegal(err.Error()), s.src[s.quoteOffset:s.offset], s.quotePos
 		}
 		tok = RAW
-		s.nextLiteral = string(s.markRawUntil)
-		s.markRawUntil = []rune{}
+		s.nextLiteral = quote
 	}
 	return
 }
 
+// illegal records reason of ILLEGAL token and returns ILLEGAL.
+func (s *Scanner) illegal(reason string) int {
+	s.illegalReason = reason
+	return ILLEGAL
+}
+
 func (s *Scanner) peek() rune {
-	if !s.reachEOF(0) {
-		return s.src[s.offset]
-	} else {
-		return -1
-	}
+	return s.readAhead(0)
 }
 
+// readAhead returns the rune which begins at offset bytes ahead of the
+// current offset, or -1 at the end of the source. offset is usually
+// the number of ASCII characters such as quotes and digits before it.
 func (s *Scanner) readAhead(offset int) rune {
-	if !s.reachEOF(offset) {
-		return s.src[s.offset+offset]
-	} else {
+	if s.reachEOF(offset) {
 		return -1
 	}
+	if ch := s.src[s.offset+offset]; ch < utf8.RuneSelf {
+		return rune(ch)
+	}
+	ch, _ := utf8.DecodeRuneInString(s.src[s.offset+offset : s.end])
+	return ch
 }
 
 func (s *Scanner) next() {
-	if !s.reachEOF(0) {
-		if s.peek() == '\n' {
-			s.lineHead = s.offset + 1
-			s.line++
-		}
+	if s.reachEOF(0) {
+		return
+	}
+	ch := s.src[s.offset]
+	if ch == '\n' {
+		s.line++
+		s.column = 0
+	} else {
+		s.column++
+	}
+	if ch < utf8.RuneSelf {
 		s.offset++
+		return
 	}
+	_, size := utf8.DecodeRuneInString(s.src[s.offset:s.end])
+	s.offset += size
 }
 
-func (s *Scanner) CurrentLine() string {
-	cursor := s.lineHead
-	var bytes []rune
-	for {
-		ch := s.src[cursor]
-
-		if ch == '\n' {
-			break
-		}
-		bytes = append(bytes, ch)
-		cursor++
-		if len(s.src) <= cursor {
-			break
-		}
+// lineAround returns the line of the source which contains the byte
+// offset in the whole source.
+func (s *Scanner) lineAround(offset int) string {
+	offset -= s.baseOffset
+	if offset < 0 || len(s.src) < offset {
+		return ""
 	}
-	return string(bytes)
+	head := strings.LastIndexByte(s.src[:offset], '\n') + 1
+	tail := strings.IndexByte(s.src[offset:], '\n')
+	if tail < 0 {
+		tail = len(s.src)
+	} else {
+		tail += offset
+	}
+	return strings.TrimSuffix(s.src[head:tail], "\r")
+}
+
+func (s *Scanner) CurrentLine() string {
+	return s.lineAround(s.baseOffset + s.offset)
 }
 
 func isLetter(ch rune) bool {
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' || ch == '$' || ch >= utf8.RuneSelf && unicode.IsLetter(ch)
 }
 
 func isNumber(ch rune) bool {
 	return '0' <= ch && ch <= '9'
 }
 
+func isHexDigit(ch rune) bool {
+	return isNumber(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+func isBitDigit(ch rune) bool {
+	return ch == '0' || ch == '1'
+}
+
 func isWhiteSpace(ch rune) bool {
-	return ch == ' ' || ch == '\t' || ch == '\n'
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
 }
 
 func (s *Scanner) reachEOF(offset int) bool {
-	return len(s.src) <= s.offset+offset
+	return s.end <= s.offset+offset
+}
+
+// hasPrefix reports whether the source at the current position begins
+// with prefix.
+func (s *Scanner) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(s.src[s.offset:s.end], prefix)
 }
 
 func (s *Scanner) position() Position {
-	return Position{Line: s.line + 1, Column: s.offset - s.lineHead + 1}
+	return Position{Line: s.line + 1, Column: s.column + 1, Offset: s.baseOffset + s.offset}
 }
 
+// skipWhiteSpace skips white spaces and comments. A comment at the head of
+// statement which is followed by ';' is not skipped because it is scanned as
+// a comment statement.
 func (s *Scanner) skipWhiteSpace() {
-	for isWhiteSpace(s.peek()) {
+	for {
+		for isWhiteSpace(s.peek()) {
+			s.next()
+		}
+		if !s.midStatement && s.isDelimiterCommand() {
+			s.scanDelimiterCommand()
+			continue
+		}
+		length := s.commentLength()
+		if length == 0 || s.isUnterminatedComment(length) {
+			return
+		}
+		if !s.midStatement && s.isFollowedBySemicolon(length) {
+			return
+		}
+		pos := s.position()
+		start := s.offset
+		text := s.scanBytes(length)
+		s.skipToken(TOKEN_KIND_COMMENT, commentText(text), start, pos)
+		if s.KeepComments {
+			s.Comments = append(s.Comments, Comment{Text: text, Pos: pos})
+		}
+	}
+}
+
+// commentLength returns the length of comment in bytes at the current offset, or 0 if
+// there is no comment. Executable comments and optimizer hints are not
+// comments. An unterminated comment lasts until EOF, which is scanned as
+// ILLEGAL.
+func (s *Scanner) commentLength() int {
+	switch ch := s.peek(); {
+	case ch == '#':
+		return s.lengthUntilLineEnd(1)
+	case ch == '-' && s.readAhead(1) == '-' && (isWhiteSpace(s.readAhead(2)) || s.readAhead(2) == -1):
+		return s.lengthUntilLineEnd(2)
+	case ch == '/' && s.readAhead(1) == '*':
+		if s.executableCommentPrefix() > 0 {
+			return 0
+		}
+		if s.readAhead(2) == '+' && optimizerHintKeywords[strings.ToUpper(s.lastLit)] {
+			return 0
+		}
+		length := 2
+		for !s.reachEOF(length) {
+			if s.readAhead(length) == '*' && s.readAhead(length+1) == '/' {
+				return length + 2
+			}
+			length++
+		}
+		return length
+	}
+	return 0
+}
+
+// isUnterminatedComment reports whether the comment of length at the
+// current offset is "/*" without "*/".
+func (s *Scanner) isUnterminatedComment(length int) bool {
+	return s.peek() == '/' && (length < 4 || s.readAhead(length-2) != '*' || s.readAhead(length-1) != '/')
+}
+
+func (s *Scanner) lengthUntilLineEnd(length int) int {
+	for !s.reachEOF(length) && s.readAhead(length) != '\n' {
+		length++
+	}
+	return length
+}
+
+// isDelimiterCommand reports whether DELIMITER command of mysql client
+// begins at the current position.
+func (s *Scanner) isDelimiterCommand() bool {
+	const command = "DELIMITER"
+	for i, ch := range command {
+		if unicode.ToUpper(s.readAhead(i)) != ch {
+			return false
+		}
+	}
+	next := s.readAhead(len(command))
+	return next == ' ' || next == '\t'
+}
+
+// scanDelimiterCommand scans DELIMITER command such as "DELIMITER //" and
+// changes the statement delimiter.
+func (s *Scanner) scanDelimiterCommand() {
+	for !isWhiteSpace(s.peek()) {
+		s.next()
+	}
+	for s.peek() == ' ' || s.peek() == '\t' {
+		s.next()
+	}
+	start := s.offset
+	for s.peek() != -1 && !isWhiteSpace(s.peek()) {
 		s.next()
 	}
+	delimiter := s.src[start:s.offset]
+	for s.peek() != -1 && s.peek() != '\n' {
+		s.next()
+	}
+	switch delimiter {
+	case "":
+	case ";":
+		s.delimiter = ""
+	default:
+		s.delimiter = delimiter
+	}
+}
+
+func (s *Scanner) isFollowedBySemicolon(length int) bool {
+	for isWhiteSpace(s.readAhead(length)) {
+		length++
+	}
+	return s.readAhead(length) == ';'
+}
+
+// scanBytes scans length bytes and returns them.
+func (s *Scanner) scanBytes(length int) string {
+	start := s.offset
+	for s.offset < start+length && !s.reachEOF(0) {
+		s.next()
+	}
+	return s.src[start:s.offset]
+}
+
+// scanComment scans a comment and returns its content without delimiters.
+func (s *Scanner) scanComment() string {
+	return commentText(s.scanBytes(s.commentLength()))
+}
+
+// commentText returns the content of comment without delimiters.
+func commentText(text string) string {
+	switch {
+	case strings.HasPrefix(text, "#"):
+		return text[1:]
+	case strings.HasPrefix(text, "--"):
+		return text[2:]
+	default:
+		return strings.TrimSuffix(text[2:], "*/")
+	}
 }
 
 func (s *Scanner) scanIdentifier() string {
-	var ret []rune
+	start := s.offset
 	for isLetter(s.peek()) || isNumber(s.peek()) {
-		ret = append(ret, s.peek())
+		if s.delimiter != "" && s.hasPrefix(s.delimiter) {
+			break
+		}
 		s.next()
 	}
+	return s.src[start:s.offset]
+}
+
+// maxKeywordLength is the length of the longest keyword.
+const maxKeywordLength = 32
+
+// upperKeyword appends the upper case of word to buf without allocation
+// so that keywords are looked up by string(word). It returns nil if word
+// is too long or not ASCII, which can't be a keyword.
+func upperKeyword(buf []byte, word string) []byte {
+	if len(word) > maxKeywordLength {
+		return nil
+	}
+	for i := 0; i < len(word); i++ {
+		ch := word[i]
+		if ch >= utf8.RuneSelf {
+			return nil
+		}
+		if 'a' <= ch && ch <= 'z' {
+			ch -= 'a' - 'A'
+		}
+		buf = append(buf, ch)
+	}
+	return buf
+}
+
+// scanUntil scans runes until finish, which is not consumed.
+func (s *Scanner) scanUntil(finish string) (string, error) {
+	start := s.offset
+	for !s.hasPrefix(finish) {
+		if s.reachEOF(0) {
+			return s.src[start:s.offset], errors.New(fmt.Sprintf("unexpected EOF, expected %q", finish))
+		}
+		s.next()
+	}
+	return s.src[start:s.offset], nil
+}
 
-	return string(ret)
+// isIdentifierQuote reports whether finish closes a quoted identifier.
+func (s *Scanner) isIdentifierQuote(finish string) bool {
+	return finish == "`" || finish == "\"" && s.ANSIQuotes
 }
 
-func (s *Scanner) scanUntil(finish []rune) (string, error) {
-	cursor := 0
-	finish_pos := len(finish) - 1
-	var ret []rune
+// scanQuotedIdentifier scans the inside of a quoted identifier until the
+// closing quote. A doubled quote stands for the quote itself.
+func (s *Scanner) scanQuotedIdentifier(quote rune) (string, error) {
+	var ret literalBuilder
+	ret.start(s)
 	for {
-		ch := s.peek()
-		if ch == finish[cursor] {
-			for {
-				cursor++
-				if cursor > finish_pos {
-					return string(ret), nil
-				}
-				ch2 := s.readAhead(cursor)
-				if ch2 != finish[cursor] {
-					cursor = 0
-					break
-				}
-				if ch2 == -1 {
-					return "", errors.New(fmt.Sprintf("unexpected EOF string. exptected \"%s\"", finish))
-				}
+		switch ch := s.peek(); {
+		case ch == -1:
+			return "", errors.New("unterminated quoted identifier")
+		case ch == quote && s.readAhead(1) == quote:
+			s.next()
+			ret.flush(s)
+			s.next()
+			ret.start(s)
+		case ch == quote:
+			return ret.string(s), nil
+		default:
+			s.next()
+		}
+	}
+}
+
+// literalBuilder builds the decoded value of a literal. The value is a
+// substring of the source unless the literal has escapes.
+type literalBuilder struct {
+	buf   []byte
+	begin int
+}
+
+// start begins a part of the value at the current offset.
+func (b *literalBuilder) start(s *Scanner) {
+	b.begin = s.offset
+}
+
+// flush appends the part from start to the current offset.
+func (b *literalBuilder) flush(s *Scanner) {
+	if b.buf == nil {
+		b.buf = make([]byte, 0, s.offset-b.begin+16)
+	}
+	b.buf = append(b.buf, s.src[b.begin:s.offset]...)
+}
+
+func (b *literalBuilder) string(s *Scanner) string {
+	if b.buf == nil {
+		return s.src[b.begin:s.offset]
+	}
+	b.flush(s)
+	return string(b.buf)
+}
+
+// isQualifier reports whether '.' at the current position qualifies the
+// preceding name such as t.1st_column rather than begins a number.
+func (s *Scanner) isQualifier() bool {
+	if s.offset == 0 {
+		return false
+	}
+	prev, _ := utf8.DecodeLastRuneInString(s.src[:s.offset])
+	return isLetter(prev) || isNumber(prev) || prev == '`' || prev == '"'
+}
+
+// scanNumeric scans a numeric literal such as 1, 1.5, .5, 1e10, 0x1F or
+// 0b101. Literals followed by letters such as "1st_table" are scanned as
+// identifiers.
+func (s *Scanner) scanNumeric() (int, string) {
+	if s.peek() == '0' && (s.readAhead(1) == 'x' || s.readAhead(1) == 'b') {
+		tok, valid := HEX_NUMBER, isHexDigit
+		if s.readAhead(1) == 'b' {
+			tok, valid = BIT_NUMBER, isBitDigit
+		}
+		i := 2
+		for valid(s.readAhead(i)) {
+			i++
+		}
+		if i > 2 && !isLetter(s.readAhead(i)) && !isNumber(s.readAhead(i)) {
+			return tok, s.scanBytes(i)
+		}
+	}
+
+	start := s.offset
+	tok := NUMBER
+	s.scanNumber()
+	if isLetter(s.peek()) && !s.isExponent() {
+		s.scanIdentifier()
+		return IDENT, s.src[start:s.offset]
+	}
+	if s.peek() == '.' {
+		s.next()
+		s.scanNumber()
+		tok = DECIMAL_NUMBER
+	}
+	if s.isExponent() {
+		s.scanBytes(2)
+		s.scanNumber()
+		tok = FLOAT_NUMBER
+	}
+	return tok, s.src[start:s.offset]
+}
+
+// isExponent reports whether the exponent of float such as "e10" or
+// "E-3" begins at the current position.
+func (s *Scanner) isExponent() bool {
+	if s.peek() != 'e' && s.peek() != 'E' {
+		return false
+	}
+	sign := s.readAhead(1)
+	return isNumber(sign) || (sign == '+' || sign == '-') && isNumber(s.readAhead(2))
+}
+
+// isStringQuote reports whether ch begins a string literal.
+func (s *Scanner) isStringQuote(ch rune) bool {
+	return ch == '\'' || ch == '"' && !s.ANSIQuotes
+}
+
+// scanQuotedString scans the inside of a string literal until the closing
+// quote, and returns the decoded string. Adjacent strings such as
+// 'a' 'b' are concatenated into one string.
+func (s *Scanner) scanQuotedString(quote rune) (string, error) {
+	var ret literalBuilder
+	ret.start(s)
+	for {
+		switch ch := s.peek(); {
+		case ch == -1:
+			return "", errors.New("unterminated string")
+		case ch == quote && s.readAhead(1) == quote:
+			s.next()
+			ret.flush(s)
+			s.next()
+			ret.start(s)
+		case ch == quote:
+			i := 1
+			for isWhiteSpace(s.readAhead(i)) {
+				i++
+			}
+			if !s.isStringQuote(s.readAhead(i)) {
+				return ret.string(s), nil
 			}
+			ret.flush(s)
+			quote = s.readAhead(i)
+			s.scanBytes(i + 1)
+			ret.start(s)
+		case ch == '\\' && !s.NoBackslashEscapes && s.readAhead(1) != -1:
+			ret.flush(s)
+			ret.buf = append(ret.buf, unescapeRune(s.readAhead(1))...)
+			s.next()
+			s.next()
+			ret.start(s)
+		default:
+			s.next()
 		}
+	}
+}
 
-		ret = append(ret, s.peek())
+// unescapeRune returns the string which is represented by backslash
+// followed by ch. "\%" and "\_" are kept as they are for LIKE patterns.
+func unescapeRune(ch rune) string {
+	switch ch {
+	case '0':
+		return "\x00"
+	case 'b':
+		return "\b"
+	case 'n':
+		return "\n"
+	case 'r':
+		return "\r"
+	case 't':
+		return "\t"
+	case 'Z':
+		return "\x1a"
+	case '%', '_':
+		return "\\" + string(ch)
+	}
+	return string(ch)
+}
+
+// scanPrefixedLiteral scans the quoted part of X'..' or B'..' including
+// the quotes.
+func (s *Scanner) scanPrefixedLiteral() string {
+	start := s.offset
+	s.next()
+	for s.peek() != -1 {
+		ch := s.peek()
 		s.next()
+		if ch == '\'' {
+			break
+		}
 	}
+	return s.src[start:s.offset]
+}
 
-	return string(ret), nil
+// scanVariableName scans the name of system variable which may be
+// qualified with its scope. (e.g. "session.sql_mode")
+func (s *Scanner) scanVariableName() string {
+	start := s.offset
+	s.scanIdentifier()
+	if s.peek() == '.' && isLetter(s.readAhead(1)) {
+		s.next()
+		s.scanIdentifier()
+	}
+	return s.src[start:s.offset]
+}
+
+// executableCommentPrefix returns the length of "/*!" or "/*M!" at the
+// current offset if the content of the executable comment should be
+// scanned as SQL, or 0 otherwise. "/*M!" is an executable comment only for
+// MariaDB.
+func (s *Scanner) executableCommentPrefix() int {
+	if s.peek() != '/' || s.readAhead(1) != '*' {
+		return 0
+	}
+	prefix := 3
+	if s.MariaDB && s.readAhead(2) == 'M' {
+		prefix = 4
+	}
+	if s.readAhead(prefix-1) != '!' {
+		return 0
+	}
+	digits := 0
+	for isNumber(s.readAhead(prefix + digits)) {
+		digits++
+	}
+	if digits == 0 || s.ServerVersion == 0 {
+		return prefix
+	}
+	var version uint
+	for i := 0; i < digits; i++ {
+		version = version*10 + uint(s.readAhead(prefix+i)-'0')
+	}
+	if version > s.ServerVersion {
+		return 0
+	}
+	return prefix
+}
+
+// isUnreservedWord reports whether the reserved word of MySQL 8.0 is an
+// identifier for the target server.
+func (s *Scanner) isUnreservedWord(word []byte) bool {
+	if !s.MariaDB && (s.ServerVersion == 0 || s.ServerVersion >= 80000) {
+		return false
+	}
+	return reservedSinceMySQL80[string(word)]
 }
 
 func (s *Scanner) scanNumber() string {
-	var ret []rune
+	start := s.offset
 	for isNumber(s.peek()) {
-		ret = append(ret, s.peek())
 		s.next()
 	}
-	return string(ret)
+	return s.src[start:s.offset]
 }
@@ -1,6 +1,8 @@
 package mysql
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -23,3 +25,246 @@ func testScanner(t *testing.T, src string, expectTok int) {
 func TestScanner(t *testing.T) {
 	// testScanner(t, "(", '(')
 }
+
+func testScanTokens(t *testing.T, s *Scanner, src string, expectToks []int) {
+	s.Init(src)
+	for _, expectTok := range expectToks {
+		tok, lit, _ := s.Scan()
+		if tok != expectTok {
+			t.Errorf("Expect Scanner{%q}.Scan() expected %#v, but got %#v (%q)", src, expectTok, tok, lit)
+			return
+		}
+	}
+	tok, _, _ := s.Scan()
+	if tok != EOF {
+		t.Errorf("Expect Scanner{%q}.Scan() expected EOF but got %#v", src, tok)
+	}
+}
+
+func TestScanExecutableComment(t *testing.T) {
+	testScanTokens(t, new(Scanner), "/*!40101 SET NAMES utf8 */;", []int{VERSION_COMMENT_START, SET, NAMES, IDENT, COMMENT_FINISH, ';'})
+	testScanTokens(t, &Scanner{ServerVersion: 40101}, "/*!40101 SET NAMES utf8 */;", []int{VERSION_COMMENT_START, SET, NAMES, IDENT, COMMENT_FINISH, ';'})
+	testScanTokens(t, &Scanner{ServerVersion: 40100}, "/*!40101 SET NAMES utf8 */;", []int{COMMENT_TEXT, ';'})
+	testScanTokens(t, new(Scanner), "ENGINE=InnoDB /*!50100 ROW_FORMAT=COMPACT */;", []int{ENGINE, '=', IDENT, ROW_FORMAT, '=', COMPACT, ';'})
+}
+
+func TestScanAccountName(t *testing.T) {
+	testScanTokens(t, new(Scanner), "'app'@'%'", []int{'\'', RAW, '\'', '@', '\'', RAW, '\''})
+	testScanTokens(t, new(Scanner), "app@localhost", []int{IDENT, USER_VARIABLE})
+}
+
+func TestScanQuotedIdentifier(t *testing.T) {
+	testScanTokens(t, new(Scanner), "`db`.`tbl`", []int{'`', RAW, '`', '.', '`', RAW, '`'})
+	testScanTokens(t, new(Scanner), "\"tbl\"", []int{'"', RAW, '"'})
+	testScanTokens(t, &Scanner{ANSIQuotes: true}, "\"tbl\"", []int{'`', RAW, '`'})
+	testScanTokens(t, new(Scanner), "1st_table 123 テーブル $col", []int{IDENT, NUMBER, IDENT, IDENT})
+
+	for src, expect := range map[string]string{
+		"`a``b`":     "a`b",
+		"````":       "`",
+		"`a\"b`":     "a\"b",
+		"\"a\"\"b\"": "a\"b",
+	} {
+		s := &Scanner{ANSIQuotes: true}
+		s.Init(src)
+		s.Scan()
+		if _, lit, _ := s.Scan(); lit != expect {
+			t.Errorf("Expect Scanner{%q}.Scan() to be %q, but got %q", src, expect, lit)
+		}
+	}
+}
+
+func TestScanString(t *testing.T) {
+	testScanTokens(t, new(Scanner), "'a' \"b\"\n'c'", []int{'\'', RAW, '\''})
+	testScanTokens(t, new(Scanner), "'a', 'b'", []int{'\'', RAW, '\'', ',', '\'', RAW, '\''})
+	testScanTokens(t, new(Scanner), "X'4D' b'101' N'a' _utf8mb4\"a\" x", []int{HEX_STRING, BIT_STRING, NATIONAL_INTRODUCER, '\'', RAW, '\'', UNDERSCORE_CHARSET, '"', RAW, '"', IDENT})
+
+	for src, expect := range map[string]string{
+		`'it''s'`:          "it's",
+		`'a\'b'`:           "a'b",
+		`"a""b\"c"`:        `a"b"c`,
+		`'C:\\path'`:       `C:\path`,
+		`'\0\b\n\r\t\Z\x'`: "\x00\b\n\r\t\x1ax",
+		`'50\%' '\_'`:      `50\%\_`,
+		`'a' "b" 'c'`:      "abc",
+		`''`:               "",
+	} {
+		s := new(Scanner)
+		s.Init(src)
+		s.Scan()
+		if _, lit, _ := s.Scan(); lit != expect {
+			t.Errorf("Expect Scanner{%q}.Scan() to be %q, but got %q", src, expect, lit)
+		}
+	}
+
+	s := &Scanner{NoBackslashEscapes: true}
+	s.Init(`'C:\path\'`)
+	s.Scan()
+	if _, lit, _ := s.Scan(); lit != `C:\path\` {
+		t.Errorf("Expect backslash not to be an escape character, but got %q", lit)
+	}
+}
+
+func TestScanNumber(t *testing.T) {
+	testScanTokens(t, new(Scanner), "1 1.5 .5 1. 1e10 1.5E-3 2e+5 0x1F 0b101", []int{NUMBER, DECIMAL_NUMBER, DECIMAL_NUMBER, DECIMAL_NUMBER, FLOAT_NUMBER, FLOAT_NUMBER, FLOAT_NUMBER, HEX_NUMBER, BIT_NUMBER})
+	testScanTokens(t, new(Scanner), "0xZZ 0b12 1ex 1st", []int{IDENT, IDENT, IDENT, IDENT})
+	testScanTokens(t, new(Scanner), "-3 (.5)", []int{'-', NUMBER, '(', DECIMAL_NUMBER, ')'})
+	testScanTokens(t, new(Scanner), "t.1st", []int{IDENT, '.', IDENT})
+}
+
+func TestScanOptimizerHint(t *testing.T) {
+	testScanTokens(t, new(Scanner), "UPDATE /*+ NO_RANGE_OPTIMIZATION(t3 PRIMARY, f2_idx) */", []int{UPDATE, OPTIMIZER_HINT})
+	testScanTokens(t, new(Scanner), "DEFAULT /*+ hoge */", []int{DEFAULT})
+}
+
+func TestScanOperator(t *testing.T) {
+	testScanTokens(t, new(Scanner), "a = ? AND b <=> ? OR c <= 1 XOR d >= 2", []int{IDENT, '=', '?', AND, IDENT, NULL_SAFE_EQUAL, '?', OR, IDENT, LE, NUMBER, XOR, IDENT, GE, NUMBER})
+	testScanTokens(t, new(Scanner), "a != b <> c < d > e", []int{IDENT, NE, IDENT, NE, IDENT, '<', IDENT, '>', IDENT})
+	testScanTokens(t, new(Scanner), "id IN (?,?)", []int{IDENT, IN, '(', '?', ',', '?', ')'})
+}
+
+func TestScanComment(t *testing.T) {
+	testScanTokens(t, new(Scanner), "/* hoge */;", []int{COMMENT_TEXT, ';'})
+	testScanTokens(t, new(Scanner), "-- hoge\n;", []int{COMMENT_TEXT, ';'})
+	testScanTokens(t, new(Scanner), "# hoge\n;", []int{COMMENT_TEXT, ';'})
+	testScanTokens(t, new(Scanner), "-- hoge\nDROP /* fuga */ TABLE # foo\n hoge -- bar", []int{DROP, TABLE, IDENT})
+
+	s := &Scanner{KeepComments: true}
+	testScanTokens(t, s, "-- hoge\r\nDROP /* fuga */ TABLE # foo", []int{DROP, TABLE})
+	expect := []Comment{
+		Comment{"-- hoge\r", Position{1, 1, 0}},
+		Comment{"/* fuga */", Position{2, 6, 14}},
+		Comment{"# foo", Position{2, 23, 31}},
+	}
+	if !reflect.DeepEqual(s.Comments, expect) {
+		t.Errorf("Expect comments %+#v, but got %+#v", expect, s.Comments)
+	}
+}
+
+// keywords of MySQL 8.0. https://dev.mysql.com/doc/refman/8.0/en/keywords.html
+var mysqlReservedKeywords = strings.Fields(`
+ACCESSIBLE ADD ALL ALTER ANALYZE AND AS ASC ASENSITIVE BEFORE BETWEEN BIGINT BINARY BLOB BOTH BY
+CALL CASCADE CASE CHANGE CHAR CHARACTER CHECK COLLATE COLUMN CONDITION CONSTRAINT CONTINUE CONVERT
+CREATE CROSS CUBE CUME_DIST CURRENT_DATE CURRENT_TIME CURRENT_TIMESTAMP CURRENT_USER CURSOR
+DATABASE DATABASES DAY_HOUR DAY_MICROSECOND DAY_MINUTE DAY_SECOND DEC DECIMAL DECLARE DEFAULT
+DELAYED DELETE DENSE_RANK DESC DESCRIBE DETERMINISTIC DISTINCT DISTINCTROW DIV DOUBLE DROP DUAL
+EACH ELSE ELSEIF EMPTY ENCLOSED ESCAPED EXCEPT EXISTS EXIT EXPLAIN FALSE FETCH FIRST_VALUE FLOAT
+FLOAT4 FLOAT8 FOR FORCE FOREIGN FROM FULLTEXT FUNCTION GENERATED GET GRANT GROUP GROUPING GROUPS
+HAVING HIGH_PRIORITY HOUR_MICROSECOND HOUR_MINUTE HOUR_SECOND IF IGNORE IN INDEX INFILE INNER INOUT
+INSENSITIVE INSERT INT INT1 INT2 INT3 INT4 INT8 INTEGER INTERSECT INTERVAL INTO IO_AFTER_GTIDS
+IO_BEFORE_GTIDS IS ITERATE JOIN JSON_TABLE KEY KEYS KILL LAG LAST_VALUE LATERAL LEAD LEADING LEAVE
+LEFT LIKE LIMIT LINEAR LINES LOAD LOCALTIME LOCALTIMESTAMP LOCK LONG LONGBLOB LONGTEXT LOOP
+LOW_PRIORITY MASTER_BIND MASTER_SSL_VERIFY_SERVER_CERT MATCH MAXVALUE MEDIUMBLOB MEDIUMINT
+MEDIUMTEXT MIDDLEINT MINUTE_MICROSECOND MINUTE_SECOND MOD MODIFIES NATURAL NOT NO_WRITE_TO_BINLOG
+NTH_VALUE NTILE NULL NUMERIC OF ON OPTIMIZE OPTIMIZER_COSTS OPTION OPTIONALLY OR ORDER OUT OUTER
+OUTFILE OVER PARTITION PERCENT_RANK PRECISION PRIMARY PROCEDURE PURGE RANGE RANK READ READS
+READ_WRITE REAL RECURSIVE REFERENCES REGEXP RELEASE RENAME REPEAT REPLACE REQUIRE RESIGNAL RESTRICT
+RETURN REVOKE RIGHT RLIKE ROW ROWS ROW_NUMBER SCHEMA SCHEMAS SECOND_MICROSECOND SELECT SENSITIVE
+SEPARATOR SET SHOW SIGNAL SMALLINT SPATIAL SPECIFIC SQL SQLEXCEPTION SQLSTATE SQLWARNING
+SQL_BIG_RESULT SQL_CALC_FOUND_ROWS SQL_SMALL_RESULT SSL STARTING STORED STRAIGHT_JOIN SYSTEM TABLE
+TERMINATED THEN TINYBLOB TINYINT TINYTEXT TO TRAILING TRIGGER TRUE UNDO UNION UNIQUE UNLOCK
+UNSIGNED UPDATE USAGE USE USING UTC_DATE UTC_TIME UTC_TIMESTAMP VALUES VARBINARY VARCHAR
+VARCHARACTER VARYING VIRTUAL WHEN WHERE WHILE WINDOW WITH WRITE XOR YEAR_MONTH ZEROFILL
+`)
+
+var mysqlNonReservedKeywords = strings.Fields(`
+ACCOUNT ACTION ACTIVE ADMIN AFTER AGAINST AGGREGATE ALGORITHM ALWAYS ANY ARRAY ASCII AT ATTRIBUTE
+AUTHENTICATION AUTOEXTEND_SIZE AUTO_INCREMENT AVG AVG_ROW_LENGTH BACKUP BEGIN BINLOG BIT BLOCK BOOL
+BOOLEAN BTREE BUCKETS BULK BYTE CACHE CASCADED CATALOG_NAME CHAIN CHALLENGE_RESPONSE CHANGED
+CHANNEL CHARSET CHECKSUM CIPHER CLASS_ORIGIN CLIENT CLONE CLOSE COALESCE CODE COLLATION COLUMNS
+COLUMN_FORMAT COLUMN_NAME COMMENT COMMIT COMMITTED COMPACT COMPLETION COMPONENT COMPRESSED
+COMPRESSION CONCURRENT CONNECTION CONSISTENT CONSTRAINT_CATALOG CONSTRAINT_NAME CONSTRAINT_SCHEMA
+CONTAINS CONTEXT CPU CURRENT CURSOR_NAME DATA DATAFILE DATE DATETIME DAY DEALLOCATE DEFAULT_AUTH
+DEFINER DEFINITION DELAY_KEY_WRITE DESCRIPTION DIAGNOSTICS DIRECTORY DISABLE DISCARD DISK DO
+DUMPFILE DUPLICATE DYNAMIC ENABLE ENCRYPTION END ENDS ENFORCED ENGINE ENGINES ENGINE_ATTRIBUTE ENUM
+ERROR ERRORS ESCAPE EVENT EVENTS EVERY EXCHANGE EXCLUDE EXECUTE EXPANSION EXPIRE EXPORT EXTENDED
+EXTENT_SIZE FACTOR FAILED_LOGIN_ATTEMPTS FAST FAULTS FIELDS FILE FILE_BLOCK_SIZE FILTER FINISH
+FIRST FIXED FLUSH FOLLOWING FOLLOWS FORMAT FOUND FULL GENERAL GEOMCOLLECTION GEOMETRY
+GEOMETRYCOLLECTION GET_FORMAT GET_MASTER_PUBLIC_KEY GET_SOURCE_PUBLIC_KEY GLOBAL GRANTS
+GROUP_REPLICATION GTID_ONLY HANDLER HASH HELP HISTOGRAM HISTORY HOST HOSTS HOUR IDENTIFIED
+IGNORE_SERVER_IDS IMPORT INACTIVE INDEXES INITIAL INITIAL_SIZE INITIATE INSERT_METHOD INSTALL
+INSTANCE INVISIBLE INVOKER IO IO_THREAD IPC ISOLATION ISSUER JSON JSON_VALUE KEYRING KEY_BLOCK_SIZE
+LANGUAGE LAST LEAVES LESS LEVEL LINESTRING LIST LOCAL LOCKED LOCKS LOGFILE LOGS MASTER
+MASTER_AUTO_POSITION MASTER_COMPRESSION_ALGORITHMS MASTER_CONNECT_RETRY MASTER_DELAY
+MASTER_HEARTBEAT_PERIOD MASTER_HOST MASTER_LOG_FILE MASTER_LOG_POS MASTER_PASSWORD MASTER_PORT
+MASTER_PUBLIC_KEY_PATH MASTER_RETRY_COUNT MASTER_SSL MASTER_SSL_CA MASTER_SSL_CAPATH
+MASTER_SSL_CERT MASTER_SSL_CIPHER MASTER_SSL_CRL MASTER_SSL_CRLPATH MASTER_SSL_KEY
+MASTER_TLS_CIPHERSUITES MASTER_TLS_VERSION MASTER_USER MASTER_ZSTD_COMPRESSION_LEVEL
+MAX_CONNECTIONS_PER_HOUR MAX_QUERIES_PER_HOUR MAX_ROWS MAX_SIZE MAX_UPDATES_PER_HOUR
+MAX_USER_CONNECTIONS MEDIUM MEMBER MEMORY MERGE MESSAGE_TEXT MICROSECOND MIGRATE MINUTE MIN_ROWS
+MODE MODIFY MONTH MULTILINESTRING MULTIPOINT MULTIPOLYGON MUTEX MYSQL_ERRNO NAME NAMES NATIONAL
+NCHAR NDB NDBCLUSTER NESTED NETWORK_NAMESPACE NEVER NEW NEXT NO NODEGROUP NONE NOWAIT NO_WAIT NULLS
+NUMBER NVARCHAR OFF OFFSET OJ OLD ONE ONLY OPEN OPTIONAL OPTIONS ORDINALITY ORGANIZATION OTHERS
+OWNER PACK_KEYS PAGE PARSER PARTIAL PARTITIONING PARTITIONS PASSWORD PASSWORD_LOCK_TIME PATH
+PERSIST PERSIST_ONLY PHASE PLUGIN PLUGINS PLUGIN_DIR POINT POLYGON PORT PRECEDES PRECEDING PREPARE
+PRESERVE PREV PRIVILEGES PRIVILEGE_CHECKS_USER PROCESS PROCESSLIST PROFILE PROFILES PROXY QUARTER
+QUERY QUICK RANDOM READ_ONLY REBUILD RECOVER REDO_BUFFER_SIZE REDUNDANT REFERENCE REGISTRATION
+RELAY RELAYLOG RELAY_LOG_FILE RELAY_LOG_POS RELAY_THREAD RELOAD REMOVE REORGANIZE REPAIR REPEATABLE
+REPLICA REPLICAS REPLICATE_DO_DB REPLICATE_DO_TABLE REPLICATE_IGNORE_DB REPLICATE_IGNORE_TABLE
+REPLICATE_REWRITE_DB REPLICATE_WILD_DO_TABLE REPLICATE_WILD_IGNORE_TABLE REPLICATION
+REQUIRE_ROW_FORMAT RESET RESOURCE RESPECT RESTART RESTORE RESUME RETAIN RETURNED_SQLSTATE RETURNING
+RETURNS REUSE REVERSE ROLE ROLLBACK ROLLUP ROTATE ROUTINE ROW_COUNT ROW_FORMAT RTREE SAVEPOINT
+SCHEDULE SCHEMA_NAME SECOND SECONDARY SECONDARY_ENGINE SECONDARY_ENGINE_ATTRIBUTE SECONDARY_LOAD
+SECONDARY_UNLOAD SECURITY SERIAL SERIALIZABLE SERVER SESSION SHARE SHUTDOWN SIGNED SIMPLE SKIP
+SLAVE SLOW SNAPSHOT SOCKET SOME SONAME SOUNDS SOURCE SOURCE_AUTO_POSITION SOURCE_BIND
+SOURCE_COMPRESSION_ALGORITHMS SOURCE_CONNECT_RETRY SOURCE_DELAY SOURCE_HEARTBEAT_PERIOD SOURCE_HOST
+SOURCE_LOG_FILE SOURCE_LOG_POS SOURCE_PASSWORD SOURCE_PORT SOURCE_PUBLIC_KEY_PATH
+SOURCE_RETRY_COUNT SOURCE_SSL SOURCE_SSL_CA SOURCE_SSL_CAPATH SOURCE_SSL_CERT SOURCE_SSL_CIPHER
+SOURCE_SSL_CRL SOURCE_SSL_CRLPATH SOURCE_SSL_KEY SOURCE_SSL_VERIFY_SERVER_CERT
+SOURCE_TLS_CIPHERSUITES SOURCE_TLS_VERSION SOURCE_USER SOURCE_ZSTD_COMPRESSION_LEVEL
+SQL_AFTER_GTIDS SQL_AFTER_MTS_GAPS SQL_BEFORE_GTIDS SQL_BUFFER_RESULT SQL_NO_CACHE SQL_THREAD
+SQL_TSI_DAY SQL_TSI_HOUR SQL_TSI_MINUTE SQL_TSI_MONTH SQL_TSI_QUARTER SQL_TSI_SECOND SQL_TSI_WEEK
+SQL_TSI_YEAR SRID STACKED START STARTS STATS_AUTO_RECALC STATS_PERSISTENT STATS_SAMPLE_PAGES STATUS
+STOP STORAGE STREAM STRING SUBCLASS_ORIGIN SUBJECT SUBPARTITION SUBPARTITIONS SUPER SUSPEND SWAPS
+SWITCHES TABLES TABLESPACE TABLE_CHECKSUM TABLE_NAME TEMPORARY TEMPTABLE TEXT THAN THREAD_PRIORITY
+TIES TIME TIMESTAMP TIMESTAMPADD TIMESTAMPDIFF TLS TRANSACTION TRIGGERS TRUNCATE TYPE TYPES
+UNBOUNDED UNCOMMITTED UNDEFINED UNDOFILE UNDO_BUFFER_SIZE UNICODE UNINSTALL UNKNOWN UNREGISTER
+UNTIL UPGRADE URL USER USER_RESOURCES USE_FRM VALIDATION VALUE VARIABLES VCPU VIEW VISIBLE WAIT
+WARNINGS WEEK WEIGHT_STRING WITHOUT WORK WRAPPER X509 XA XID XML YEAR ZONE
+`)
+
+func TestScanIllegal(t *testing.T) {
+	testScanTokens(t, new(Scanner), "SELECT 'abc", []int{SELECT, '\'', ILLEGAL})
+	testScanTokens(t, new(Scanner), "DROP TABLE `abc", []int{DROP, TABLE, '`', ILLEGAL})
+	testScanTokens(t, new(Scanner), "SELECT /*+ BKA(t) ", []int{SELECT, ILLEGAL})
+	testScanTokens(t, new(Scanner), "SELECT 1 /* comment", []int{SELECT, NUMBER, ILLEGAL})
+	testScanTokens(t, new(Scanner), "SELECT ^ 1", []int{SELECT, ILLEGAL, NUMBER})
+
+	s := new(Scanner)
+	s.Init("")
+	if line := s.CurrentLine(); line != "" {
+		t.Errorf("Expect current line of empty source to be empty, but got %q", line)
+	}
+}
+
+func TestIsReservedKeyword(t *testing.T) {
+	for _, word := range mysqlReservedKeywords {
+		if !IsReservedKeyword(word) || !IsReservedKeyword(strings.ToLower(word)) {
+			t.Errorf("Expect %q to be reserved", word)
+		}
+	}
+	for _, word := range mysqlNonReservedKeywords {
+		if IsReservedKeyword(word) {
+			t.Errorf("Expect %q not to be reserved", word)
+		}
+	}
+	for word := range keywords {
+		if !IsReservedKeyword(word) && !contains(mysqlNonReservedKeywords, word) {
+			t.Errorf("Expect keyword %q to be listed in MySQL keywords", word)
+		}
+	}
+}
+
+func TestScanReservedKeyword(t *testing.T) {
+	testScanTokens(t, new(Scanner), "SELECT accessible, ROW_NUMBER", []int{SELECT, RESERVED_KEYWORD, ',', RESERVED_KEYWORD})
+	testScanTokens(t, new(Scanner), "date timestamp accessibles", []int{DATE, TIMESTAMP, IDENT})
+}
+
+func contains(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
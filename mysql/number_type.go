@@ -0,0 +1,28 @@
+package mysql
+
+type NumberType uint
+
+const (
+	NUMBER_TYPE_INTEGER NumberType = iota
+	NUMBER_TYPE_DECIMAL
+	NUMBER_TYPE_FLOAT
+	NUMBER_TYPE_HEX
+	NUMBER_TYPE_BIT
+)
+
+func (t NumberType) String() string {
+	switch t {
+	case NUMBER_TYPE_INTEGER:
+		return "INTEGER"
+	case NUMBER_TYPE_DECIMAL:
+		return "DECIMAL"
+	case NUMBER_TYPE_FLOAT:
+		return "FLOAT"
+	case NUMBER_TYPE_HEX:
+		return "HEX"
+	case NUMBER_TYPE_BIT:
+		return "BIT"
+	default:
+		return ""
+	}
+}
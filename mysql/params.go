@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"reflect"
+	"sort"
+)
+
+var paramExpressionType = reflect.TypeOf(&ParamExpression{})
+
+// Params returns the placeholders in stmt ordered by their ordinal positions.
+// The number of bind parameters a query requires is len(Params(stmt)).
+func Params(stmt Statement) []*ParamExpression {
+	var params []*ParamExpression
+	collectParams(reflect.ValueOf(stmt), &params)
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].Ordinal < params[j].Ordinal
+	})
+	return params
+}
+
+func collectParams(v reflect.Value, params *[]*ParamExpression) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		if v.Type() == paramExpressionType {
+			*params = append(*params, v.Interface().(*ParamExpression))
+			return
+		}
+		collectParams(v.Elem(), params)
+	case reflect.Interface:
+		if !v.IsNil() {
+			collectParams(v.Elem(), params)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			collectParams(v.Field(i), params)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			collectParams(v.Index(i), params)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package mysql
+
+import (
+	"testing"
+)
+
+func TestParams(t *testing.T) {
+	for _, c := range []struct {
+		src    string
+		expect int
+	}{
+		{"SELECT * FROM users WHERE id = ?;", 1},
+		{"SELECT * FROM users WHERE id IN (?, ?, ?) AND name LIKE ? LIMIT ?, ?;", 6},
+		{"INSERT INTO users (id, name) VALUES (?, ?), (?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name);", 4},
+		{"UPDATE users SET name = ? WHERE id = ? AND deleted_at IS NULL;", 2},
+		{"DELETE FROM users WHERE id BETWEEN ? AND ?;", 2},
+		{"SELECT * FROM users WHERE id IN (SELECT user_id FROM posts WHERE id = ?) AND id > ?;", 2},
+		{"SELECT ?; SELECT ?, ?;", 1},
+		{"CREATE TABLE hoge ( id INT );", 0},
+	} {
+		s := new(Scanner)
+		s.Init(c.src)
+		statements, err := Parse(s)
+		if err != nil {
+			t.Errorf("Parse failed %s", err)
+			continue
+		}
+		params := Params(statements[0])
+		if len(params) != c.expect {
+			t.Errorf("Expect %q to have %d params, but got %d", c.src, c.expect, len(params))
+			continue
+		}
+		for i, param := range params {
+			if param.Ordinal != i+1 {
+				t.Errorf("Expect ordinal of param %d in %q to be %d, but got %d", i, c.src, i+1, param.Ordinal)
+			}
+		}
+	}
+}
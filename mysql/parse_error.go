@@ -0,0 +1,153 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError is the error returned by Parse when the source is not valid.
+type ParseError struct {
+	// Message describes the error such as "syntax error".
+	Message string
+	// Position is the position of the offending token.
+	Position Position
+	// Token is the offending token such as IDENT or '(', or EOF at the end
+	// of the source. Literal is the literal of the token.
+	Token   int
+	Literal string
+	// Expected is the names of tokens which are acceptable at the position
+	// such as "IDENT" or "'('". It is empty if the error is not a syntax
+	// error but an invalid value such as a malformed hexadecimal literal or
+	// a role in the privileges of GRANT.
+	Expected []string
+	// StatementIndex is the index of the statement containing the error,
+	// counted from 0.
+	StatementIndex int
+	// Line is the source line containing the error.
+	Line string
+}
+
+func (e *ParseError) Error() string {
+	if e.Token == EOF {
+		return fmt.Sprintf("%s at end of input line %d, col: %d", e.Message, e.Position.Line, e.Position.Column)
+	}
+	return fmt.Sprintf("%s while processing near %q line %d, col: %d", e.Message, e.Literal, e.Position.Line, e.Position.Column)
+}
+
+// Format implements fmt.Formatter. "%+v" renders the line containing the
+// error with a caret which points the offending token.
+func (e *ParseError) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		// Column is zero if the position is unknown.
+		indent := e.Position.Column - 1
+		if indent < 0 {
+			indent = 0
+		}
+		fmt.Fprintf(f, "%s\n%s\n%s^\n", e.Error(), e.Line, strings.Repeat(" ", indent))
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", e.Error())
+	default:
+		fmt.Fprint(f, e.Error())
+	}
+}
+
+// expectedTokens returns the names of tokens which the parser can shift
+// after chars except the last one, which is the offending token.
+func expectedTokens(chars []int) []string {
+	if len(chars) == 0 {
+		return nil
+	}
+	stack := []int{0}
+	for _, char := range chars[:len(chars)-1] {
+		var ok bool
+		if stack, ok = shiftToken(stack, tokenNumber(char)); !ok {
+			return nil
+		}
+	}
+	var expected []string
+	for token := 1; token <= len(yyToknames); token++ {
+		if token == yyErrCode || yyToknames[token-1] == "$unk" {
+			continue
+		}
+		if _, ok := shiftToken(append([]int(nil), stack...), token); ok {
+			expected = append(expected, tokenName(token))
+		}
+	}
+	return expected
+}
+
+// shiftToken runs the parser tables from the states in stack until token
+// is shifted, in the same way as yyParse does. It returns false if token
+// is a syntax error.
+func shiftToken(stack []int, token int) ([]int, bool) {
+	for {
+		state := stack[len(stack)-1]
+		n := int(yyPact[state])
+		if n > yyFlag && n+token >= 0 && n+token < yyLast {
+			if next := int(yyAct[n+token]); int(yyChk[next]) == token {
+				return append(stack, next), true
+			}
+		}
+		n = int(yyDef[state])
+		if n == -2 {
+			i := 0
+			for yyExca[i] != -1 || int(yyExca[i+1]) != state {
+				i += 2
+			}
+			for i += 2; yyExca[i] >= 0 && int(yyExca[i]) != token; i += 2 {
+			}
+			n = int(yyExca[i+1])
+			if n < 0 {
+				// accepted
+				return stack, true
+			}
+		}
+		if n == 0 {
+			return stack, false
+		}
+
+		// reduce by the rule n and go to the next state.
+		stack = stack[:len(stack)-int(yyR2[n])]
+		lhs := int(yyR1[n])
+		next := int(yyAct[yyPgo[lhs]])
+		if j := int(yyPgo[lhs]) + stack[len(stack)-1] + 1; j < yyLast {
+			if state := int(yyAct[j]); int(yyChk[state]) == -lhs {
+				next = state
+			}
+		}
+		stack = append(stack, next)
+	}
+}
+
+// tokenNumber converts the token returned by Lex into the internal number
+// of the parser in the same way as yylex1 does.
+func tokenNumber(char int) int {
+	token := 0
+	switch {
+	case char <= 0:
+		token = int(yyTok1[0])
+	case char < len(yyTok1):
+		token = int(yyTok1[char])
+	case char >= yyPrivate && char < yyPrivate+len(yyTok2):
+		token = int(yyTok2[char-yyPrivate])
+	default:
+		for i := 0; i < len(yyTok3); i += 2 {
+			if int(yyTok3[i]) == char {
+				token = int(yyTok3[i+1])
+				break
+			}
+		}
+	}
+	if token == 0 {
+		token = int(yyTok2[1])
+	}
+	return token
+}
+
+func tokenName(token int) string {
+	if token == 1 {
+		return "EOF"
+	}
+	return yyTokname(token)
+}
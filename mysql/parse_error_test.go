@@ -0,0 +1,157 @@
+package mysql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testParseError(t *testing.T, src string) *ParseError {
+	s := new(Scanner)
+	s.Init(src)
+	_, err := Parse(s)
+	if err == nil {
+		t.Errorf("Expect %q not to be parsed", src)
+		return nil
+	}
+	parseError, ok := err.(*ParseError)
+	if !ok {
+		t.Errorf("Expect *ParseError, but got %#v", err)
+		return nil
+	}
+	return parseError
+}
+
+func TestParseError(t *testing.T) {
+	e := testParseError(t, "SELECT 1;\nDROP TABLE hoge;\nDROP TABLE ,;")
+	if e == nil {
+		return
+	}
+	expect := &ParseError{
+		Message:        "syntax error",
+		Position:       Position{Line: 3, Column: 12, Offset: 38},
+		Token:          ',',
+		Literal:        ",",
+		Expected:       e.Expected,
+		StatementIndex: 2,
+		Line:           "DROP TABLE ,;",
+	}
+	if !reflect.DeepEqual(e, expect) {
+		t.Errorf("Expect %+#v, but got %+#v", expect, e)
+	}
+	for _, token := range []string{"IDENT", "'`'", "TEMPORARY"} {
+		if !contains(e.Expected, token) {
+			t.Errorf("Expect %s to be expected, but got %v", token, e.Expected)
+		}
+	}
+	if contains(e.Expected, "','") || contains(e.Expected, "EOF") {
+		t.Errorf("Expect ',' and EOF not to be expected, but got %v", e.Expected)
+	}
+
+	if msg := e.Error(); msg != `syntax error while processing near "," line 3, col: 12` {
+		t.Errorf("Unexpected message %q", msg)
+	}
+	if msg := fmt.Sprintf("%+v", e); msg != "syntax error while processing near \",\" line 3, col: 12\nDROP TABLE ,;\n           ^\n" {
+		t.Errorf("Unexpected message %q", msg)
+	}
+}
+
+func TestParseErrorAtEOF(t *testing.T) {
+	e := testParseError(t, "SELECT * FROM")
+	if e == nil {
+		return
+	}
+	if e.Token != EOF || e.Position != (Position{Line: 1, Column: 14, Offset: 13}) {
+		t.Errorf("Expect EOF at the end of source, but got %d at %+v", e.Token, e.Position)
+	}
+	if !contains(e.Expected, "IDENT") || !contains(e.Expected, "'('") || contains(e.Expected, "EOF") {
+		t.Errorf("Unexpected expected tokens %v", e.Expected)
+	}
+
+	e = testParseError(t, "SELECT 1")
+	if e != nil && !contains(e.Expected, "';'") {
+		t.Errorf("Expect ';' to be expected, but got %v", e.Expected)
+	}
+}
+
+func TestParseErrorKeepsCurrentStatement(t *testing.T) {
+	s := new(Scanner)
+	s.Init(strings.Repeat("SELECT a, b, c FROM t WHERE id = 1;\n", 100) + "SELECT * FROM")
+	l := LexerWrapper{scanner: s}
+	_, err := l.parse()
+	if err == nil {
+		t.Fatal("Expect an error")
+	}
+	if len(l.chars) != 4 {
+		t.Errorf("Expect the tokens of the last statement, but got %d tokens", len(l.chars))
+	}
+	if e := err.(*ParseError); !contains(e.Expected, "IDENT") || e.StatementIndex != 100 {
+		t.Errorf("Unexpected error %+#v", e)
+	}
+}
+
+func TestParseErrorInvalidValue(t *testing.T) {
+	e := testParseError(t, "SELECT X'4G';")
+	if e == nil {
+		return
+	}
+	if e.Token != HEX_STRING || e.Expected != nil || e.Message != "invalid hexadecimal literal" {
+		t.Errorf("Expect the error about invalid value, but got %+#v", e)
+	}
+
+	e = testParseError(t, "CREATE TABLE t (id INT)\n  AUTO_INCREMENT=99999999999999999999999;")
+	if e == nil {
+		return
+	}
+	expect := &ParseError{
+		Message:  "number out of range",
+		Position: Position{Line: 2, Column: 18, Offset: 41},
+		Token:    NUMBER,
+		Literal:  "99999999999999999999999",
+		Line:     "  AUTO_INCREMENT=99999999999999999999999;",
+	}
+	if !reflect.DeepEqual(e, expect) {
+		t.Errorf("Expect %+#v, but got %+#v", expect, e)
+	}
+}
+
+func TestParseErrorRoleOrPrivilege(t *testing.T) {
+	for _, c := range []struct {
+		src     string
+		message string
+		literal string
+	}{
+		{"GRANT SELECT, 'admin'@'%' ON *.* TO u;", "role is not allowed with ON", "'admin'@'%'"},
+		{"GRANT admin, INSERT (id) TO u;", "privilege is not allowed without ON", "INSERT (id)"},
+		{"REVOKE CREATE USER FROM u;", "privilege is not allowed without ON", "CREATE USER"},
+	} {
+		e := testParseError(t, c.src)
+		if e == nil {
+			continue
+		}
+		if e.Message != c.message || e.Literal != c.literal || e.Expected != nil {
+			t.Errorf("Expect the error %q about %q of %q, but got %+#v", c.message, c.literal, c.src, e)
+		}
+	}
+}
+
+func TestParseErrorFormat(t *testing.T) {
+	e := testParseError(t, "SELECT * FROM")
+	if e != nil && e.Error() != "syntax error at end of input line 1, col: 14" {
+		t.Errorf("Unexpected message %q", e.Error())
+	}
+	if msg := fmt.Sprintf("%+v", &ParseError{Message: "x"}); msg != "x while processing near \"\" line 0, col: 0\n\n^\n" {
+		t.Errorf("Unexpected message %q", msg)
+	}
+}
+
+func TestParseErrorIllegal(t *testing.T) {
+	e := testParseError(t, "SELECT 1;\nSELECT 'abc")
+	if e == nil {
+		return
+	}
+	if e.Token != ILLEGAL || e.Message != "unterminated string" || e.Literal != "'abc" || e.Position != (Position{Line: 2, Column: 8, Offset: 17}) {
+		t.Errorf("Expect the error about unterminated string, but got %+#v", e)
+	}
+}
@@ -3,15 +3,19 @@
 package mysql
 
 import (
-    "fmt"
     "strconv"
-    "errors"
+    "strings"
+    "sync"
 )
 
-type Token struct {
+type lexerToken struct {
     tok int
     lit string
     pos Position
+    // the position just after the token
+    end Position
+    // true if the token is VERSION_COMMENT_START of /*M! or in the comment.
+    mariaDB bool
 }
 
 %}
@@ -36,15 +40,73 @@ type Token struct {
     bool bool
     data_type_type DataType
     default_definition DefaultDefinition
+    set_assignments []SetAssignment
+    set_assignment SetAssignment
+    expression Expression
+    expressions []Expression
+    partition_options *PartitionOptions
+    partition_method PartitionMethod
+    partition_definitions []PartitionDefinition
+    partition_definition PartitionDefinition
+    subpartition_definitions []SubpartitionDefinition
+    subpartition_definition SubpartitionDefinition
+    partition_names []PartitionNameIdentifier
+    partition_name PartitionNameIdentifier
     uint uint
+    uint64 uint64
+    int64 int64
+    account_name AccountNameIdentifier
+    account_names []AccountNameIdentifier
+    user_specification UserSpecification
+    user_specifications []UserSpecification
+    auth_option *AuthOption
+    role_or_privilege roleOrPrivilege
+    role_or_privileges []roleOrPrivilege
+    privilege_level PrivilegeLevel
+    server_option ServerOption
+    server_options []ServerOption
+    strs []string
+    table_lock TableLock
+    table_locks []TableLock
+    select_statement *SelectStatement
+    query_expression QueryExpression
+    paren_query_expression *ParenQueryExpression
+    with_clause *WithClause
+    common_table_expression CommonTableExpression
+    common_table_expressions []CommonTableExpression
+    select_lock selectLock
+    function_call *FunctionCallExpression
+    window_specification *WindowSpecification
+    window_definition WindowDefinition
+    window_definitions []WindowDefinition
+    window_frame *WindowFrame
+    window_frame_bound WindowFrameBound
+    json_table_column JSONTableColumn
+    json_table_columns []JSONTableColumn
+    select_field SelectField
+    select_fields []SelectField
+    table_reference TableReference
+    table_references []TableReference
+    order_by_item OrderByItem
+    order_by_items []OrderByItem
+    limit *Limit
+    insert_statement *InsertStatement
+    insert_options insertOptions
+    insert_rows [][]Expression
+    assignment Assignment
+    assignments []Assignment
+    delete_statement *DeleteStatement
+    explain_statement *ExplainStatement
+    show_filter *ShowFilter
     fraction_option [2]uint
-    tok       Token
+    number_expression *NumberExpression
+    tok       lexerToken
     str string
 }
 
 %type<statements> statements
-%type<statement> statement
-%type<table_names> table_names
+%type<statement> statement statement_body create_table_statement explainable_statement
+%type<table_names> table_names table_name_list
 %type<table_name> table_name
 %type<database_name> database_name
 %type<column_name> column_name
@@ -52,26 +114,151 @@ type Token struct {
 %type<index_name> index_name skipable_index_name
 %type<column_definition> column_definition
 %type<alter_specifications> alter_specifications
-%type<alter_specification> alter_specification
+%type<alter_specification> alter_specification alter_partition_specification
 %type<create_definition> create_definition
 %type<create_definitions> create_definitions
 %type<data_type> data_type
 %type<data_type_type> data_type_number data_type_fraction data_type_decimal
-%type<bool> unsigned_option zerofill_option nullable autoincrement
+%type<bool> unsigned_option zerofill_option nullable autoincrement generated_storage
 %type<uint> length_option
 %type<fraction_option> fraction_option decimal_option
 %type<default_definition> default
-%type<table_option> table_option
-%type<table_options> skipable_table_options
-%type<str> storage_engine_name string
+%type<table_option> table_option partition_definition_option database_option
+%type<table_options> skipable_table_options table_options alter_table_options partition_definition_options database_options
+%type<str> storage_engine_name string variable_scope charset_name function_name name quoted_string insert_method row_format
+%type<uint64> number
+%type<number_expression> number_literal signed_number_literal
+%type<int64> number_or_default
+%type<set_assignments> set_assignments
+%type<set_assignment> set_assignment
+%type<expression> variable set_value expression simple_expression literal column_reference partition_value
+%type<expressions> expressions partition_value_list
+%type<partition_options> partition_options
+%type<partition_method> partition_method subpartition_method
+%type<partition_definitions> partition_definitions partition_definitions_option
+%type<partition_definition> partition_definition partition_values
+%type<subpartition_definitions> subpartition_definitions subpartition_definitions_option
+%type<subpartition_definition> subpartition_definition
+%type<partition_names> partition_names
+%type<partition_name> partition_name
+%type<bool> linear
+%type<uint> key_algorithm partition_count subpartition_count
+%type<tok> ident non_reserved_keyword
+%type<bool> if_not_exists if_exists with_grant_option with_admin_option
+%type<str> name_or_string account_lock_option account_name_part quoted_role_name privilege_type
+%type<account_name> account_name
+%type<account_names> account_names default_role_option
+%type<user_specification> user_specification
+%type<user_specifications> user_specifications
+%type<auth_option> auth_option
+%type<role_or_privilege> role_or_privilege
+%type<role_or_privileges> role_or_privileges
+%type<privilege_level> privilege_level privilege_level_name
+%type<bool> undo
+%type<str> tablespace_datafile tablespace_logfile_group
+%type<table_options> tablespace_options tablespace_option_list
+%type<table_option> tablespace_option
+%type<uint64> size
+%type<server_options> server_options
+%type<server_option> server_option
+%type<strs> transaction_characteristics check_options flush_options
+%type<str> transaction_characteristic completion_chain completion_release lock_type check_option flush_option flush_tables_lock
+%type<bool> no_write_to_binlog
+%type<table_lock> table_lock
+%type<table_locks> table_locks
+%type<select_statement> select_statement
+%type<query_expression> query_expression query_expression_body query_term query_primary
+%type<paren_query_expression> query_parens
+%type<with_clause> with_clause
+%type<common_table_expression> common_table_expression
+%type<common_table_expressions> common_table_expressions
+%type<column_names> cte_columns
+%type<bool> set_quantifier
+%type<select_lock> select_lock
+%type<table_names> lock_of
+%type<str> lock_option window_name json_table_response
+%type<strs> json_table_responses
+%type<function_call> function_call
+%type<window_specification> window_specification
+%type<window_definition> window_definition
+%type<window_definitions> window_clause window_definitions
+%type<window_frame> window_frame
+%type<window_frame_bound> window_frame_bound
+%type<str> window_frame_unit
+%type<expressions> partition_by_clause
+%type<json_table_column> json_table_column
+%type<json_table_columns> json_table_columns
+%type<str> optimizer_hints alias join_type insert_priority is_value comparison_operator
+%type<bool> select_options insert_or_replace low_priority ignore
+%type<select_field> select_field
+%type<select_fields> select_fields
+%type<table_references> from_clause table_references
+%type<table_reference> table_reference table_factor
+%type<expression> where_clause having_clause limit_value expression_or_default boolean_primary predicate bit_expression
+%type<expressions> group_by_clause insert_row insert_row_values user_variables
+%type<order_by_item> order_by_item
+%type<order_by_items> order_by_clause order_by_items
+%type<limit> limit_clause
+%type<insert_statement> insert_values
+%type<insert_options> insert_options
+%type<column_names> insert_columns
+%type<insert_rows> insert_rows
+%type<assignment> assignment
+%type<assignments> assignments on_duplicate_key_update
+%type<delete_statement> delete_options
+%type<explain_statement> explain_options
+%type<bool> skipable_full
+%type<database_name> show_from_database
+%type<show_filter> show_filter
+%type<str> show_scope
 
-%token<tok> IDENT NUMBER RAW COMMENT_START COMMENT_FINISH
+%token<tok> IDENT RESERVED_KEYWORD NUMBER RAW COMMENT_TEXT COMMENT_FINISH VERSION_COMMENT_START OPTIMIZER_HINT
+%token<tok> DECIMAL_NUMBER FLOAT_NUMBER HEX_NUMBER BIT_NUMBER HEX_STRING BIT_STRING NATIONAL_INTRODUCER UNDERSCORE_CHARSET
+%token<tok> INNER_SEMICOLON ILLEGAL CONCAT_PIPES
+%token<tok> USER_VARIABLE SYSTEM_VARIABLE NAMES GLOBAL SESSION LOCAL PERSIST PERSIST_ONLY
 %token<tok> DROP CREATE ALTER ADD
 %token<tok> TABLE COLUMN DATABASE INDEX KEY NOT NULL AUTO_INCREMENT DEFAULT CURRENT_TIMESTAMP ON UPDATE PRIMARY UNIQUE
 %token<tok> USING BTREE HASH CHARSET CHARACTER SET COLLATE
 %token<tok> ENGINE AVG_ROW_LENGTH CHECKSUM COMMENT KEY_BLOCK_SIZE MAX_ROWS MIN_ROWS ROW_FORMAT DYNAMIC FIXED COMPRESSED REDUNDANT COMPACT
+%token<tok> DATA DIRECTORY TABLESPACE STORAGE NODEGROUP IN ALL INTO WITH WITHOUT VALIDATION DIV MOD
+%token<tok> PARTITION PARTITIONS SUBPARTITION SUBPARTITIONS PARTITIONING BY LINEAR ALGORITHM RANGE LIST COLUMNS VALUES LESS THAN MAXVALUE
+%token<tok> REORGANIZE TRUNCATE EXCHANGE COALESCE REMOVE ENABLE DISABLE
+%token<tok> GENERATED ALWAYS VIRTUAL STORED
+%token<tok> AUTOEXTEND_SIZE COMPRESSION CONNECTION DELAY_KEY_WRITE ENCRYPTION ENGINE_ATTRIBUTE INSERT_METHOD PACK_KEYS PASSWORD
+%token<tok> SECONDARY_ENGINE SECONDARY_ENGINE_ATTRIBUTE STATS_AUTO_RECALC STATS_PERSISTENT STATS_SAMPLE_PAGES UNION DISK MEMORY NO FIRST LAST
+%token<tok> USER ROLE GRANT REVOKE IDENTIFIED RANDOM AS TO FROM FOR OPTION ADMIN PRIVILEGES IF EXISTS ACCOUNT LOCK UNLOCK NONE CURRENT_USER
+%token<tok> FUNCTION PROCEDURE ROUTINE TEMPORARY TABLES VIEW SHOW DATABASES SELECT INSERT DELETE REFERENCES REPLICATION CLIENT SLAVE TRIGGER USAGE
+%token<tok> UNDO DATAFILE UNDOFILE LOGFILE GROUP USE FILE_BLOCK_SIZE EXTENT_SIZE INITIAL_SIZE MAX_SIZE UNDO_BUFFER_SIZE REDO_BUFFER_SIZE WAIT NO_WAIT
+%token<tok> RENAME ACTIVE INACTIVE SERVER FOREIGN WRAPPER OPTIONS HOST SOCKET OWNER PORT
+%token<tok> START TRANSACTION BEGIN WORK COMMIT ROLLBACK SAVEPOINT RELEASE CHAIN CONSISTENT SNAPSHOT READ WRITE ONLY AND LOW_PRIORITY
+%token<tok> ANALYZE OPTIMIZE CHECK NO_WRITE_TO_BINLOG QUICK FAST MEDIUM EXTENDED CHANGED UPGRADE
+%token<tok> FLUSH LOGS ERROR GENERAL HOSTS OPTIMIZER_COSTS RELAY SLOW STATUS USER_RESOURCES EXPORT
+%token<tok> DISTINCT WHERE HAVING ORDER ASC DESC LIMIT OFFSET JOIN INNER CROSS LEFT RIGHT OUTER NATURAL STRAIGHT_JOIN DUAL SHARE MODE
+%token<tok> OVER ROWS PRECEDING FOLLOWING UNBOUNDED CURRENT ROW WINDOW RECURSIVE INTERSECT EXCEPT LATERAL
+%token<tok> JSON_TABLE PATH ORDINALITY NESTED SKIP LOCKED NOWAIT OF EMPTY
+%token<tok> EXPLAIN DESCRIBE FORMAT FULL FIELDS INDEXES KEYS VARIABLES PROCESSLIST WARNINGS ERRORS GRANTS
+%token<tok> IGNORE VALUE DUPLICATE REPLACE DELAYED HIGH_PRIORITY OR XOR IS LIKE ESCAPE BETWEEN TRUE FALSE UNKNOWN_SYM
+%token<tok> PREPARE EXECUTE DEALLOCATE LE GE NE NULL_SAFE_EQUAL
 %token<tok> BIT TINYINT SMALLINT MEDIUMINT INT INTEGER BIGINT REAL DOUBLE FLOAT DECIMAL NUMERIC DATE TIME TIMESTAMP DATETIME YEAR CHAR VARCHAR BINARY VARBINARY TINYBLOB BLOB MEDIUMBLOB LONGBLOB TINYTEXT TEXT MEDIUMTEXT LONGTEXT UNSIGNED ZEROFILL
 
+%nonassoc LOWER_THAN_PAREN
+%nonassoc LIKE_WITHOUT_ESCAPE NONE UNBOUNDED
+%nonassoc ESCAPE PRECEDING FOLLOWING TO
+%nonassoc '('
+%nonassoc SUBQUERY
+%left UNION EXCEPT
+%left INTERSECT
+%nonassoc JOIN_WITHOUT_CONDITION
+%nonassoc ON USING
+%left OR
+%left XOR
+%left AND
+%right NOT
+%left '+' '-'
+%left '*' '/' '%' DIV MOD
+%left CONCAT_PIPES
+%right UNARY
+
 %%
 
 statements
@@ -84,667 +271,4268 @@ statements
     }
     | statements statement
     {
+        recordVersionGates(yylex, $2)
         $$ = append($1, $2)
         if l, isLexerWrapper := yylex.(*LexerWrapper); isLexerWrapper {
             l.statements = $$
+            l.paramCount = 0
+            l.dropChars()
         }
     }
 
 statement
-    : DROP TABLE table_names ';'
+    : statement_body ';'
     {
-        $$ = &DropTableStatement{TableNames: $3}
+        $$ = $1
     }
-    | DROP DATABASE database_name ';'
+    | create_table_statement
     {
-        $$ = &DropDatabaseStatement{DatabaseName: $3}
+        $$ = $1
     }
-    | CREATE DATABASE database_name ';'
+    | VERSION_COMMENT_START statement_body COMMENT_FINISH ';'
     {
-        $$ = &CreateDatabaseStatement{DatabaseName: $3}
+        version, err := strconv.Atoi($1.lit)
+        if err != nil {
+            version = 0
+        }
+        $$ = &ExecutableCommentStatement{Span: tokenSpan($1, $3), Version: uint(version), MariaDB: $1.mariaDB, Statement: $2}
     }
-    | CREATE TABLE table_name '(' create_definitions ')' skipable_table_options optional_statement_finish
+    | COMMENT_TEXT ';'
     {
-        $$ = &CreateTableStatement{TableName: $3, CreateDefinitions: $5, TableOptions: $7}
+        $$ = &CommentStatement{Span: tokenSpan($1, $1), Content: $1.lit}
     }
-    | ALTER TABLE table_name alter_specifications ';'
+
+statement_body
+    : DROP TABLE if_exists table_names
     {
-        $$ = &AlterTableStatement{TableName: $3, AlterSpecifications: $4}
+        $$ = &DropTableStatement{Span: newSpan(yylex, $<tok>1), IfExists: $3, TableNames: $4}
     }
-    | COMMENT_START RAW COMMENT_FINISH ';'
+    | DROP DATABASE if_exists database_name
     {
-        $$ = &CommentStatement{$2.lit}
+        $$ = &DropDatabaseStatement{Span: newSpan(yylex, $<tok>1), IfExists: $3, DatabaseName: $4}
     }
-
-optional_statement_finish
-    :
-    | ';'
-
-create_definitions
-    : create_definition
+    | CREATE DATABASE database_name database_options
     {
-        $$ = []CreateDefinition{$1}
+        $$ = &CreateDatabaseStatement{Span: newSpan(yylex, $<tok>1), DatabaseName: $3, Options: $4}
     }
-    | create_definitions ',' create_definition
+    | CREATE DATABASE IF NOT EXISTS database_name database_options
     {
-        $$ = append($1, $3)
+        // mysqldump encloses IF NOT EXISTS in an executable comment.
+        gate, _ := claimVersionGate(yylex, $<tok>3.pos, $<tok>5.end)
+        $$ = &CreateDatabaseStatement{Span: newSpan(yylex, $<tok>1), IfNotExists: true, IfNotExistsGate: gate, DatabaseName: $6, Options: $7}
     }
-
-create_definition
-    : column_name column_definition
+    | USE database_name
     {
-        $$ = &CreateDefinitionColumn{ColumnName: $1, ColumnDefinition: $2}
+        $$ = &UseStatement{Span: newSpan(yylex, $<tok>1), DatabaseName: $2}
     }
-    | PRIMARY KEY skipable_index_type '(' index_column_names ')'
+    | create_table_statement
     {
-        $$ = &CreateDefinitionPrimaryIndex{Columns: $5}
+        $$ = $1
     }
-    | index_or_key skipable_index_name skipable_index_type '(' index_column_names ')' skipable_index_type
+    | ALTER TABLE table_name alter_specifications
     {
-        $$ = &CreateDefinitionIndex{Name: $2, Columns: $5}
+        $$ = &AlterTableStatement{Span: newSpan(yylex, $<tok>1), TableName: $3, AlterSpecifications: $4}
     }
-    | UNIQUE index_or_key skipable_index_name skipable_index_type '(' index_column_names ')'
+    | ALTER TABLE table_name alter_specifications partition_options
     {
-        $$ = &CreateDefinitionUniqueIndex{Name: $3, Columns: $6}
+        $$ = &AlterTableStatement{Span: newSpan(yylex, $<tok>1), TableName: $3, AlterSpecifications: append($4, &AlterSpecificationPartitionBy{Span: newSpan(yylex, $<tok>5), PartitionOptions: $5})}
     }
-
-skipable_table_options
- :
- {
-    $$ = []TableOption{}
- }
- | skipable_table_options table_option
- {
-    $$ = append($1, $2)
- }
-
-table_option
-    : ENGINE skipable_equal storage_engine_name
+    | ALTER TABLE table_name alter_partition_specification
     {
-        var option TableOption
-        option.Key = "ENGINE"
-        option.Value = $3
-        $$ = option
+        $$ = &AlterTableStatement{Span: newSpan(yylex, $<tok>1), TableName: $3, AlterSpecifications: []AlterSpecification{$4}}
     }
-    | AUTO_INCREMENT skipable_equal NUMBER
+    | SET set_assignments
     {
-        var option TableOption
-        option.Key = "AUTO_INCREMENT"
-        option.Value = $3.lit
-        $$ = option
+        $$ = &SetStatement{Span: newSpan(yylex, $<tok>1), Assignments: $2}
     }
-    | AVG_ROW_LENGTH skipable_equal NUMBER
+    | CREATE USER if_not_exists user_specifications default_role_option account_lock_option
     {
-        var option TableOption
-        option.Key = "AVG_ROW_LENGTH"
-        option.Value = $3.lit
-        $$ = option
+        $$ = &CreateUserStatement{Span: newSpan(yylex, $<tok>1), IfNotExists: $3, Users: $4, DefaultRoles: $5, AccountLock: $6}
     }
-    | CHECKSUM skipable_equal NUMBER
+    | ALTER USER if_exists user_specifications account_lock_option
     {
-        var option TableOption
-        option.Key = "CHECKSUM"
-        option.Value = $3.lit
-        $$ = option
+        $$ = &AlterUserStatement{Span: newSpan(yylex, $<tok>1), IfExists: $3, Users: $4, AccountLock: $5}
     }
-    | COMMENT skipable_equal '\'' RAW '\''
+    | DROP USER if_exists account_names
     {
-        var option TableOption
-        option.Key = "COMMENT"
-        option.Value = $4.lit
-        $$ = option
+        $$ = &DropUserStatement{Span: newSpan(yylex, $<tok>1), IfExists: $3, Users: $4}
     }
-    | KEY_BLOCK_SIZE skipable_equal NUMBER
+    | CREATE ROLE if_not_exists account_names
     {
-        var option TableOption
-        option.Key = "KEY_BLOCK_SIZE"
-        option.Value = $3.lit
-        $$ = option
+        $$ = &CreateRoleStatement{Span: newSpan(yylex, $<tok>1), IfNotExists: $3, Roles: $4}
     }
-    | MAX_ROWS skipable_equal NUMBER
+    | DROP ROLE if_exists account_names
     {
-        var option TableOption
-        option.Key = "MAX_ROWS"
-        option.Value = $3.lit
-        $$ = option
+        $$ = &DropRoleStatement{Span: newSpan(yylex, $<tok>1), IfExists: $3, Roles: $4}
     }
-    | MIN_ROWS skipable_equal NUMBER
+    | GRANT role_or_privileges ON privilege_level TO account_names with_grant_option
     {
-        var option TableOption
-        option.Key = "MIN_ROWS"
-        option.Value = $3.lit
-        $$ = option
+        privileges, ok := toPrivileges(yylex, $2)
+        if !ok {
+            return 1
+        }
+        $$ = &GrantStatement{Span: newSpan(yylex, $<tok>1), Privileges: privileges, Level: $4, Users: $6, WithGrantOption: $7}
     }
-    | ROW_FORMAT skipable_equal storage_engine_name
+    | GRANT role_or_privileges TO account_names with_admin_option
     {
-        var option TableOption
-        option.Key = "ROW_FORMAT"
-        option.Value = $3
-        $$ = option
+        roles, ok := toRoles(yylex, $2)
+        if !ok {
+            return 1
+        }
+        $$ = &GrantRoleStatement{Span: newSpan(yylex, $<tok>1), Roles: roles, Users: $4, WithAdminOption: $5}
     }
-    | DEFAULT CHARSET skipable_equal string
+    | REVOKE role_or_privileges ON privilege_level FROM account_names
     {
-        var option TableOption
-        option.Key = "DEFAULT CHARACTER SET"
-        option.Value = $4
-        $$ = option
+        privileges, ok := toPrivileges(yylex, $2)
+        if !ok {
+            return 1
+        }
+        $$ = &RevokeStatement{Span: newSpan(yylex, $<tok>1), Privileges: privileges, Level: $4, Users: $6}
     }
-    | COLLATE skipable_equal string
+    | REVOKE role_or_privileges FROM account_names
     {
-        var option TableOption
-        option.Key = "COLLATE"
-        option.Value = $3
-        $$ = option
+        if isRevokeAll($2) {
+            $$ = &RevokeAllStatement{Span: newSpan(yylex, $<tok>1), Users: $4}
+        } else {
+            roles, ok := toRoles(yylex, $2)
+            if !ok {
+                return 1
+            }
+            $$ = &RevokeRoleStatement{Span: newSpan(yylex, $<tok>1), Roles: roles, Users: $4}
+        }
     }
-
-charset_or_character_set
-    : CHARSET
-    | CHARACTER SET
-
-skipable_equal
-    :
-    | '='
-
-index_column_names
-    : column_name
+    | SET DEFAULT ROLE NONE TO account_names
     {
-        result := []ColumnNameIdentifier{}
-        result = append(result, $1)
-        $$ = result
+        $$ = &SetDefaultRoleStatement{Span: newSpan(yylex, $<tok>1), Users: $6}
     }
-    | index_column_names ',' column_name
+    | SET DEFAULT ROLE ALL TO account_names
     {
-        result := append($1, $3)
-        $$ = result
+        $$ = &SetDefaultRoleStatement{Span: newSpan(yylex, $<tok>1), All: true, Users: $6}
     }
-
-skipable_index_type
-    :
-    | USING BTREE
-    | USING HASH
-
-table_names
-    : table_name
+    | SET DEFAULT ROLE account_names TO account_names
     {
-        $$ = []TableNameIdentifier{$1}
+        $$ = &SetDefaultRoleStatement{Span: newSpan(yylex, $<tok>1), Roles: $4, Users: $6}
     }
-    | table_names ',' table_name
+    | SET PASSWORD '=' quoted_string
     {
-        $$ = append([]TableNameIdentifier{$3}, $1...)
+        $$ = &SetPasswordStatement{Span: newSpan(yylex, $<tok>1), Password: $4}
     }
-
-table_name
-    : IDENT
+    | SET PASSWORD FOR account_name '=' quoted_string
     {
-        $$ = TableNameIdentifier{Name: $1.lit}
+        account := $4
+        $$ = &SetPasswordStatement{Span: newSpan(yylex, $<tok>1), Account: &account, Password: $6}
     }
-    | '`' RAW '`'
+    | CREATE undo TABLESPACE name tablespace_datafile tablespace_logfile_group tablespace_options
     {
-        $$ = TableNameIdentifier{Name: $2.lit}
+        $$ = &CreateTablespaceStatement{Span: newSpan(yylex, $<tok>1), Undo: $2, Name: $4, DataFile: $5, LogfileGroup: $6, Options: $7}
     }
-    | IDENT '.' IDENT
+    | ALTER undo TABLESPACE name ADD DATAFILE quoted_string tablespace_options
     {
-        $$ = TableNameIdentifier{Database: $1.lit, Name: $3.lit}
+        $$ = &AlterTablespaceStatement{Span: newSpan(yylex, $<tok>1), Undo: $2, Name: $4, DataFileOperation: "ADD", DataFile: $7, Options: $8}
     }
-
-database_name
-    : IDENT
+    | ALTER undo TABLESPACE name DROP DATAFILE quoted_string tablespace_options
     {
-        $$ = DatabaseNameIdentifier{Name: $1.lit}
+        $$ = &AlterTablespaceStatement{Span: newSpan(yylex, $<tok>1), Undo: $2, Name: $4, DataFileOperation: "DROP", DataFile: $7, Options: $8}
     }
-    | '`' RAW '`'
+    | ALTER undo TABLESPACE name RENAME TO name
     {
-        $$ = DatabaseNameIdentifier{Name: $2.lit}
+        $$ = &AlterTablespaceStatement{Span: newSpan(yylex, $<tok>1), Undo: $2, Name: $4, RenameTo: $7}
     }
-
-alter_specifications
-    :
+    | ALTER undo TABLESPACE name SET ACTIVE
     {
-        $$ = nil
+        $$ = &AlterTablespaceStatement{Span: newSpan(yylex, $<tok>1), Undo: $2, Name: $4, Set: "ACTIVE"}
     }
-    | alter_specification
+    | ALTER undo TABLESPACE name SET INACTIVE
     {
-        $$ = []AlterSpecification{$1}
+        $$ = &AlterTablespaceStatement{Span: newSpan(yylex, $<tok>1), Undo: $2, Name: $4, Set: "INACTIVE"}
     }
-    | alter_specifications ',' alter_specification
+    | ALTER undo TABLESPACE name tablespace_option_list
     {
-        $$ = append($1, $3)
+        $$ = &AlterTablespaceStatement{Span: newSpan(yylex, $<tok>1), Undo: $2, Name: $4, Options: $5}
     }
-
-alter_specification
-    : ADD skipable_column column_name column_definition
+    | DROP undo TABLESPACE name tablespace_options
     {
-        $$ = &AlterSpecificationAddColumn{ColumnName: $3, ColumnDefinition: $4}
+        $$ = &DropTablespaceStatement{Span: newSpan(yylex, $<tok>1), Undo: $2, Name: $4, Options: $5}
     }
-    | ADD index_or_key skipable_index_name skipable_index_type '(' index_column_names ')'
+    | CREATE LOGFILE GROUP name ADD UNDOFILE quoted_string tablespace_options
     {
-        $$ = &AlterSpecificationAddIndex{Name: $3, Columns: $6, Unique: false}
+        $$ = &CreateLogfileGroupStatement{Span: newSpan(yylex, $<tok>1), Name: $4, UndoFile: $7, Options: $8}
     }
-    | ADD UNIQUE index_or_key skipable_index_name skipable_index_type '(' index_column_names ')'
+    | ALTER LOGFILE GROUP name ADD UNDOFILE quoted_string tablespace_options
     {
-        $$ = &AlterSpecificationAddIndex{Name: $4, Columns: $7, Unique: true}
+        $$ = &AlterLogfileGroupStatement{Span: newSpan(yylex, $<tok>1), Name: $4, UndoFile: $7, Options: $8}
     }
-    | DROP index_or_key index_name
+    | DROP LOGFILE GROUP name tablespace_options
     {
-        $$ = &AlterSpecificationDropIndex{Name: $3}
+        $$ = &DropLogfileGroupStatement{Span: newSpan(yylex, $<tok>1), Name: $4, Options: $5}
     }
-    | DROP skipable_column column_name
+    | CREATE SERVER name_or_string FOREIGN DATA WRAPPER name_or_string OPTIONS '(' server_options ')'
     {
-        $$ = &AlterSpecificationDropColumn{ColumnName: $3}
+        $$ = &CreateServerStatement{Span: newSpan(yylex, $<tok>1), Name: $3, Wrapper: $7, Options: $10}
     }
-
-skipable_column
-    :
-    | COLUMN
-
-column_definition
-    : data_type nullable default autoincrement key_options column_comment
+    | ALTER SERVER name_or_string OPTIONS '(' server_options ')'
     {
-        $$ = ColumnDefinition{$1, $2, $4, $3}
+        $$ = &AlterServerStatement{Span: newSpan(yylex, $<tok>1), Name: $3, Options: $6}
     }
-
-nullable
-    :
+    | DROP SERVER if_exists name_or_string
     {
-        $$ = true
+        $$ = &DropServerStatement{Span: newSpan(yylex, $<tok>1), IfExists: $3, Name: $4}
     }
-    | NULL
+    | START TRANSACTION
     {
-        $$ = true
+        $$ = &StartTransactionStatement{Span: newSpan(yylex, $<tok>1)}
     }
-    | NOT NULL
+    | START TRANSACTION transaction_characteristics
     {
-        $$ = false
+        $$ = &StartTransactionStatement{Span: newSpan(yylex, $<tok>1), Characteristics: $3}
     }
-
-default
-    :
+    | BEGIN skipable_work
     {
-        $$ = &DefaultDefinitionEmpty{}
+        $$ = &BeginStatement{Span: newSpan(yylex, $<tok>1)}
     }
-    | DEFAULT NULL
+    | COMMIT skipable_work completion_chain completion_release
     {
-        $$ = &DefaultDefinitionNull{}
+        $$ = &CommitStatement{Span: newSpan(yylex, $<tok>1), Chain: $3, Release: $4}
     }
-    | DEFAULT NUMBER
+    | ROLLBACK skipable_work completion_chain completion_release
     {
-        value := DefaultDefinitionString{}
-        value.Value = $2.lit
-        $$ = &value
+        $$ = &RollbackStatement{Span: newSpan(yylex, $<tok>1), Chain: $3, Release: $4}
     }
-    | DEFAULT '"' RAW '"'
+    | ROLLBACK skipable_work TO ident
     {
-        value := DefaultDefinitionString{}
-        value.Value = $3.lit
-        $$ = &value
+        $$ = &RollbackToSavepointStatement{Span: newSpan(yylex, $<tok>1), Name: $4.lit}
     }
-    | DEFAULT '\'' RAW '\''
+    | ROLLBACK skipable_work TO SAVEPOINT ident
     {
-        value := DefaultDefinitionString{}
-        value.Value = $3.lit
-        $$ = &value
+        $$ = &RollbackToSavepointStatement{Span: newSpan(yylex, $<tok>1), Name: $5.lit}
     }
-    | DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    | SAVEPOINT ident
     {
-        $$ = &DefaultDefinitionCurrentTimestamp{true}
+        $$ = &SavepointStatement{Span: newSpan(yylex, $<tok>1), Name: $2.lit}
     }
-    | DEFAULT CURRENT_TIMESTAMP
+    | RELEASE SAVEPOINT ident
     {
-        $$ = &DefaultDefinitionCurrentTimestamp{false}
+        $$ = &ReleaseSavepointStatement{Span: newSpan(yylex, $<tok>1), Name: $3.lit}
     }
-
-string
-    : IDENT
+    | LOCK table_or_tables table_locks
     {
-        $$ = $1.lit
+        $$ = &LockTablesStatement{Span: newSpan(yylex, $<tok>1), Locks: $3}
     }
-    | '\'' RAW '\''
+    | UNLOCK table_or_tables
     {
-        $$ = $2.lit
+        $$ = &UnlockTablesStatement{Span: newSpan(yylex, $<tok>1)}
     }
-    | '"' RAW '"'
+    | ANALYZE no_write_to_binlog table_or_tables table_name_list
     {
-        $$ = $2.lit
+        $$ = &AnalyzeTableStatement{Span: newSpan(yylex, $<tok>1), NoWriteToBinlog: $2, TableNames: $4}
     }
-
-autoincrement
-    :
+    | OPTIMIZE no_write_to_binlog table_or_tables table_name_list
     {
-        $$ = false
+        $$ = &OptimizeTableStatement{Span: newSpan(yylex, $<tok>1), NoWriteToBinlog: $2, TableNames: $4}
     }
-    | AUTO_INCREMENT
+    | CHECK table_or_tables table_name_list check_options
     {
-        $$ = true
+        $$ = &CheckTableStatement{Span: newSpan(yylex, $<tok>1), TableNames: $3, Options: $4}
     }
-
-key_options
-    :
-
-column_comment
-    :
-    | COMMENT string
-
-data_type
-    : BIT
+    | FLUSH no_write_to_binlog flush_options
     {
-        $$ = &DataTypeDefinitionSimple{Type: DATATYPE_BIT }
+        $$ = &FlushStatement{Span: newSpan(yylex, $<tok>1), NoWriteToBinlog: $2, Options: $3}
     }
-    | data_type_number length_option unsigned_option zerofill_option
+    | FLUSH no_write_to_binlog table_or_tables flush_tables_lock
     {
-        $$ = &DataTypeDefinitionNumber{Type: $1, Length: $2, Unsigned: $3, Zerofill: $4 }
+        $$ = &FlushTablesStatement{Span: newSpan(yylex, $<tok>1), NoWriteToBinlog: $2, Lock: $4}
     }
-    | data_type_fraction fraction_option unsigned_option zerofill_option
+    | FLUSH no_write_to_binlog table_or_tables table_name_list flush_tables_lock
     {
-        fraction := $2
-        $$ = &DataTypeDefinitionFraction{Type: $1, Length: fraction[0], Decimals: fraction[1], Unsigned: $3, Zerofill: $4 }
+        $$ = &FlushTablesStatement{Span: newSpan(yylex, $<tok>1), NoWriteToBinlog: $2, TableNames: $4, Lock: $5}
     }
-    | data_type_decimal decimal_option unsigned_option zerofill_option
+    | explainable_statement
     {
-        fraction := $2
-        $$ = &DataTypeDefinitionFraction{Type: $1, Length: fraction[0], Decimals: fraction[1], Unsigned: $3, Zerofill: $4 }
+        $$ = $1
     }
-    | DATE
+    | explain_keyword table_name
     {
-        $$ = &DataTypeDefinitionSimple{Type: DATATYPE_DATE }
+        $$ = &DescribeStatement{Span: newSpan(yylex, $<tok>1), TableName: $2}
     }
-    | TIME
+    | explain_keyword table_name name_or_string
     {
-        $$ = &DataTypeDefinitionSimple{Type: DATATYPE_TIME }
+        $$ = &DescribeStatement{Span: newSpan(yylex, $<tok>1), TableName: $2, Column: $3}
     }
-    | TIMESTAMP
+    | explain_keyword explain_options explainable_statement
     {
-        $$ = &DataTypeDefinitionSimple{Type: DATATYPE_TIMESTAMP }
+        stmt := $2
+        stmt.Span = newSpan(yylex, $<tok>1)
+        stmt.Statement = $3
+        $$ = stmt
     }
-    | DATETIME
+    | SHOW CREATE TABLE table_name
     {
-        $$ = &DataTypeDefinitionSimple{Type: DATATYPE_DATETIME }
+        $$ = &ShowCreateTableStatement{Span: newSpan(yylex, $<tok>1), TableName: $4}
     }
-    | YEAR
+    | SHOW CREATE DATABASE database_name
     {
-        $$ = &DataTypeDefinitionSimple{Type: DATATYPE_YEAR }
+        $$ = &ShowCreateDatabaseStatement{Span: newSpan(yylex, $<tok>1), DatabaseName: $4}
     }
-    | CHAR length_option optional_character_set optional_collate
+    | SHOW CREATE DATABASE IF NOT EXISTS database_name
     {
-        $$ = &DataTypeDefinitionString{Type: DATATYPE_CHAR, Length: $2 }
+        $$ = &ShowCreateDatabaseStatement{Span: newSpan(yylex, $<tok>1), IfNotExists: true, DatabaseName: $7}
     }
-    | VARCHAR length_option optional_character_set optional_collate
+    | SHOW DATABASES show_filter
     {
-        $$ = &DataTypeDefinitionString{Type: DATATYPE_VARCHAR, Length: $2 }
+        $$ = &ShowDatabasesStatement{Span: newSpan(yylex, $<tok>1), Filter: $3}
     }
-    | BINARY
+    | SHOW skipable_full TABLES show_from_database show_filter
     {
-        $$ = &DataTypeDefinitionSimple{Type: DATATYPE_BINARY }
+        $$ = &ShowTablesStatement{Span: newSpan(yylex, $<tok>1), Full: $2, Database: $4, Filter: $5}
     }
-    | VARBINARY
+    | SHOW skipable_full columns_or_fields from_or_in table_name show_from_database show_filter
     {
-        $$ = &DataTypeDefinitionSimple{Type: DATATYPE_VARBINARY }
+        tableName := $5
+        if $6.Name != "" {
+            tableName.Database = $6.Name
+        }
+        $$ = &ShowColumnsStatement{Span: newSpan(yylex, $<tok>1), Full: $2, TableName: tableName, Filter: $7}
     }
-    | TINYBLOB
+    | SHOW index_or_indexes_or_keys from_or_in table_name show_from_database where_clause
     {
-        $$ = &DataTypeDefinitionSimple{Type: DATATYPE_TINYBLOB }
+        tableName := $4
+        if $5.Name != "" {
+            tableName.Database = $5.Name
+        }
+        $$ = &ShowIndexStatement{Span: newSpan(yylex, $<tok>1), TableName: tableName, Where: $6}
     }
-    | BLOB
+    | SHOW show_scope VARIABLES show_filter
     {
-        $$ = &DataTypeDefinitionSimple{Type: DATATYPE_BLOB }
+        $$ = &ShowVariablesStatement{Span: newSpan(yylex, $<tok>1), Scope: $2, Filter: $4}
     }
-    | MEDIUMBLOB
+    | SHOW show_scope STATUS show_filter
     {
-        $$ = &DataTypeDefinitionSimple{Type: DATATYPE_MEDIUMBLOB }
+        $$ = &ShowStatusStatement{Span: newSpan(yylex, $<tok>1), Scope: $2, Filter: $4}
     }
-    | LONGBLOB
+    | SHOW skipable_full PROCESSLIST
     {
-        $$ = &DataTypeDefinitionSimple{Type: DATATYPE_LONGBLOB }
+        $$ = &ShowProcesslistStatement{Span: newSpan(yylex, $<tok>1), Full: $2}
     }
-    | TINYTEXT
+    | SHOW WARNINGS limit_clause
     {
-        $$ = &DataTypeDefinitionTextBlob{Type: DATATYPE_TINYTEXT }
+        $$ = &ShowWarningsStatement{Span: newSpan(yylex, $<tok>1), Limit: $3}
     }
-    | TEXT optional_character_set optional_collate
+    | SHOW ERRORS limit_clause
     {
-        $$ = &DataTypeDefinitionTextBlob{Type: DATATYPE_TEXT }
+        $$ = &ShowErrorsStatement{Span: newSpan(yylex, $<tok>1), Limit: $3}
     }
-    | MEDIUMTEXT optional_character_set optional_collate
+    | SHOW GRANTS
     {
-        $$ = &DataTypeDefinitionTextBlob{Type: DATATYPE_MEDIUMTEXT }
+        $$ = &ShowGrantsStatement{Span: newSpan(yylex, $<tok>1)}
     }
-    | LONGTEXT optional_character_set optional_collate
+    | SHOW GRANTS FOR account_name
     {
-        $$ = &DataTypeDefinitionTextBlob{Type: DATATYPE_LONGTEXT }
+        account := $4
+        $$ = &ShowGrantsStatement{Span: newSpan(yylex, $<tok>1), For: &account}
     }
-
-data_type_number
-    : TINYINT
+    | PREPARE ident FROM literal
     {
-        $$ = DATATYPE_TINYINT
+        $$ = &PrepareStatement{Span: newSpan(yylex, $<tok>1), Name: $2.lit, Text: $4}
     }
-    | SMALLINT
+    | PREPARE ident FROM USER_VARIABLE
     {
-        $$ = DATATYPE_SMALLINT
+        $$ = &PrepareStatement{Span: newSpan(yylex, $<tok>1), Name: $2.lit, Text: &UserVariableExpression{Span: tokenSpan($4, $4), Name: $4.lit}}
     }
-    | MEDIUMINT
+    | EXECUTE ident
     {
-        $$ = DATATYPE_MEDIUMINT
+        $$ = &ExecuteStatement{Span: newSpan(yylex, $<tok>1), Name: $2.lit}
     }
-    | INT
+    | EXECUTE ident USING user_variables
     {
-        $$ = DATATYPE_INT
+        $$ = &ExecuteStatement{Span: newSpan(yylex, $<tok>1), Name: $2.lit, Using: $4}
     }
-    | INTEGER
+    | DEALLOCATE PREPARE ident
     {
-        $$ = DATATYPE_INT
+        $$ = &DeallocatePrepareStatement{Span: newSpan(yylex, $<tok>1), Name: $3.lit}
     }
-    | BIGINT
+    | DROP PREPARE ident
     {
-        $$ = DATATYPE_BIGINT
+        $$ = &DeallocatePrepareStatement{Span: newSpan(yylex, $<tok>1), Name: $3.lit}
     }
 
-data_type_fraction
-    : REAL
-    {
-        $$ = DATATYPE_REAL
-    }
-    | DOUBLE
+create_table_statement
+    : CREATE TABLE table_name '(' create_definitions ')' skipable_table_options
     {
-        $$ = DATATYPE_DOUBLE
+        $$ = &CreateTableStatement{Span: newSpan(yylex, $<tok>1), TableName: $3, CreateDefinitions: $5, TableOptions: $7}
     }
-    | FLOAT
+    | CREATE TABLE table_name '(' create_definitions ')' skipable_table_options partition_options
     {
-        $$ = DATATYPE_FLOAT
+        $$ = &CreateTableStatement{Span: newSpan(yylex, $<tok>1), TableName: $3, CreateDefinitions: $5, TableOptions: $7, PartitionOptions: $8}
     }
 
-data_type_decimal
-    : DECIMAL
+partition_options
+    : PARTITION BY partition_method partition_count partition_definitions_option
     {
-        $$ = DATATYPE_DECIMAL
+        $$ = &PartitionOptions{Span: newSpan(yylex, $<tok>1), PartitionBy: $3, Partitions: $4, Definitions: $5}
+        setVersionGate(yylex, $$)
     }
-    | NUMERIC
+    | PARTITION BY partition_method partition_count SUBPARTITION BY subpartition_method subpartition_count partition_definitions_option
     {
-        $$ = DATATYPE_NUMERIC
+        subpartitionBy := $7
+        $$ = &PartitionOptions{Span: newSpan(yylex, $<tok>1), PartitionBy: $3, Partitions: $4, SubpartitionBy: &subpartitionBy, Subpartitions: $8, Definitions: $9}
+        setVersionGate(yylex, $$)
     }
 
-length_option
-    :
+partition_method
+    : subpartition_method
     {
-        $$ = 0
+        $$ = $1
     }
-    | '(' NUMBER ')'
+    | RANGE '(' expression ')'
     {
-        num, err := strconv.Atoi($2.lit)
-        if err != nil {
-            num = 0
-        }
-        $$ = uint(num)
+        $$ = PartitionMethod{Span: newSpan(yylex, $<tok>1), Type: PARTITION_TYPE_RANGE, Expression: $3}
     }
-
-fraction_option
-    :
+    | RANGE COLUMNS '(' index_column_names ')'
     {
-        $$ = [2]uint{0, 0}
+        $$ = PartitionMethod{Span: newSpan(yylex, $<tok>1), Type: PARTITION_TYPE_RANGE_COLUMNS, Columns: $4}
     }
-    | '(' NUMBER ',' NUMBER ')'
+    | LIST '(' expression ')'
     {
-        num1, err := strconv.Atoi($2.lit)
-        if err != nil {
-            num1 = 0
-        }
-        num2, err := strconv.Atoi($4.lit)
-        if err != nil {
-            num2 = 0
-        }
-        result := [2]uint{0, 0}
-        result[0] = uint(num1)
-        result[1] = uint(num2)
-        $$ = result
+        $$ = PartitionMethod{Span: newSpan(yylex, $<tok>1), Type: PARTITION_TYPE_LIST, Expression: $3}
     }
-
-optional_character_set
-    :
-    | CHARACTER SET string
-
-optional_collate
-    :
-    | COLLATE string
-
-decimal_option
-    :
+    | LIST COLUMNS '(' index_column_names ')'
     {
-        $$ = [2]uint{0, 0}
+        $$ = PartitionMethod{Span: newSpan(yylex, $<tok>1), Type: PARTITION_TYPE_LIST_COLUMNS, Columns: $4}
     }
-    | '(' NUMBER ')'
+
+subpartition_method
+    : linear HASH '(' expression ')'
     {
-        result := [2]uint{0, 0}
-        num1, err := strconv.Atoi($2.lit)
-        if err != nil {
-            num1 = 0
+        start := $<tok>2
+        if $1 {
+            start = $<tok>1
         }
-        result[0] = uint(num1)
-        $$ = result
+        $$ = PartitionMethod{Span: newSpan(yylex, start), Type: PARTITION_TYPE_HASH, Linear: $1, Expression: $4}
     }
-    | '(' NUMBER ',' NUMBER ')'
+    | linear KEY key_algorithm '(' ')'
     {
-        num1, err := strconv.Atoi($2.lit)
-        if err != nil {
-            num1 = 0
+        start := $<tok>2
+        if $1 {
+            start = $<tok>1
         }
-        num2, err := strconv.Atoi($4.lit)
-        if err != nil {
-            num2 = 0
+        $$ = PartitionMethod{Span: newSpan(yylex, start), Type: PARTITION_TYPE_KEY, Linear: $1, Algorithm: $3}
+    }
+    | linear KEY key_algorithm '(' index_column_names ')'
+    {
+        start := $<tok>2
+        if $1 {
+            start = $<tok>1
         }
-        result := [2]uint{0, 0}
-        result[0] = uint(num1)
-        result[1] = uint(num2)
-        $$ = result
+        $$ = PartitionMethod{Span: newSpan(yylex, start), Type: PARTITION_TYPE_KEY, Linear: $1, Algorithm: $3, Columns: $5}
     }
 
-unsigned_option
+linear
     :
     {
         $$ = false
     }
-    | UNSIGNED
+    | LINEAR
     {
         $$ = true
     }
 
-zerofill_option
+key_algorithm
     :
     {
-        $$ = false
+        $$ = 0
     }
-    | ZEROFILL
+    | ALGORITHM '=' number
     {
-        $$ = true
+        $$ = uint($3)
     }
 
-
-index_or_key
-    : INDEX
-    | KEY
-
-column_name
-    : IDENT
+partition_count
+    :
     {
-        $$ = ColumnNameIdentifier{Name: $1.lit}
+        $$ = 0
     }
-    | '`' RAW '`'
+    | PARTITIONS number
     {
-        $$ = ColumnNameIdentifier{Name: $2.lit}
+        $$ = uint($2)
     }
 
-skipable_index_name
+subpartition_count
     :
     {
-        $$ = IndexNameIdentifier{Name: ""}
+        $$ = 0
     }
-    | index_name
+    | SUBPARTITIONS number
     {
-        $$ = $1
+        $$ = uint($2)
     }
 
-index_name
-    : IDENT
+partition_definitions_option
+    : %prec LOWER_THAN_PAREN
     {
-        $$ = IndexNameIdentifier{Name: $1.lit}
+        $$ = nil
     }
-    | '`' RAW '`'
+    | '(' partition_definitions ')'
     {
-        $$ = IndexNameIdentifier{Name: $2.lit}
+        $$ = $2
     }
 
-storage_engine_name
-    : IDENT
+partition_definitions
+    : partition_definition
     {
-        $$ = $1.lit
+        $$ = []PartitionDefinition{$1}
     }
-    | '\'' IDENT '\''
+    | partition_definitions ',' partition_definition
     {
-        $$ = $2.lit
+        $$ = append($1, $3)
     }
-    | '"' IDENT '\''
+
+partition_definition
+    : PARTITION partition_name partition_values partition_definition_options subpartition_definitions_option
     {
-        $$ = $2.lit
+        definition := $3
+        definition.Span = newSpan(yylex, $<tok>1)
+        definition.Name = $2
+        definition.Options = $4
+        definition.Subpartitions = $5
+        $$ = definition
     }
 
-skipable_default
+partition_values
     :
+    {
+        $$ = PartitionDefinition{}
+    }
+    | VALUES LESS THAN MAXVALUE
+    {
+        $$ = PartitionDefinition{ValuesLessThan: []Expression{&MaxValueExpression{Span: tokenSpan($4, $4)}}}
+    }
+    | VALUES LESS THAN '(' partition_value_list ')'
+    {
+        $$ = PartitionDefinition{ValuesLessThan: $5}
+    }
+    | VALUES IN '(' partition_value_list ')'
+    {
+        $$ = PartitionDefinition{ValuesIn: $4}
+    }
+
+partition_value_list
+    : partition_value
+    {
+        $$ = []Expression{$1}
+    }
+    | partition_value_list ',' partition_value
+    {
+        $$ = append($1, $3)
+    }
+
+partition_value
+    : expression
+    {
+        $$ = $1
+    }
+    | MAXVALUE
+    {
+        $$ = &MaxValueExpression{Span: newSpan(yylex, $<tok>1)}
+    }
+
+partition_definition_options
+    :
+    {
+        $$ = nil
+    }
+    | partition_definition_options partition_definition_option
+    {
+        $$ = append($1, $2)
+    }
+
+partition_definition_option
+    : ENGINE skipable_equal storage_engine_name
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, $<tok>1), Key: "ENGINE", Value: $3}
+    }
+    | STORAGE ENGINE skipable_equal storage_engine_name
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, $<tok>1), Key: "ENGINE", Value: $4}
+    }
+    | COMMENT skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "COMMENT", Value: $3}
+    }
+    | DATA DIRECTORY skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "DATA DIRECTORY", Value: $4}
+    }
+    | INDEX DIRECTORY skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "INDEX DIRECTORY", Value: $4}
+    }
+    | MAX_ROWS skipable_equal number
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "MAX_ROWS", Value: $3}
+    }
+    | MIN_ROWS skipable_equal number
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "MIN_ROWS", Value: $3}
+    }
+    | TABLESPACE skipable_equal name
+    {
+        $$ = &TableOptionTablespace{Span: newSpan(yylex, $<tok>1), Name: $3}
+    }
+    | NODEGROUP skipable_equal number
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "NODEGROUP", Value: $3}
+    }
+
+subpartition_definitions_option
+    :
+    {
+        $$ = nil
+    }
+    | '(' subpartition_definitions ')'
+    {
+        $$ = $2
+    }
+
+subpartition_definitions
+    : subpartition_definition
+    {
+        $$ = []SubpartitionDefinition{$1}
+    }
+    | subpartition_definitions ',' subpartition_definition
+    {
+        $$ = append($1, $3)
+    }
+
+subpartition_definition
+    : SUBPARTITION partition_name partition_definition_options
+    {
+        $$ = SubpartitionDefinition{Span: newSpan(yylex, $<tok>1), Name: $2, Options: $3}
+    }
+
+partition_names
+    : partition_name
+    {
+        $$ = []PartitionNameIdentifier{$1}
+    }
+    | partition_names ',' partition_name
+    {
+        $$ = append($1, $3)
+    }
+
+partition_name
+    : name
+    {
+        $$ = PartitionNameIdentifier{Span: newSpan(yylex, $<tok>1), Name: $1}
+    }
+
+set_assignments
+    : set_assignment
+    {
+        $$ = []SetAssignment{$1}
+    }
+    | set_assignments ',' set_assignment
+    {
+        $$ = append($1, $3)
+    }
+
+set_assignment
+    : variable '=' set_value
+    {
+        $$ = &SetAssignmentVariable{Span: newSpan(yylex, $<tok>1), Variable: $1, Value: $3}
+    }
+    | NAMES charset_name
+    {
+        $$ = &SetAssignmentNames{Span: newSpan(yylex, $<tok>1), Charset: $2}
+    }
+    | NAMES charset_name COLLATE string
+    {
+        $$ = &SetAssignmentNames{Span: newSpan(yylex, $<tok>1), Charset: $2, Collation: $4}
+    }
+    | charset_or_character_set charset_name
+    {
+        $$ = &SetAssignmentCharset{Span: newSpan(yylex, $<tok>1), Charset: $2}
+    }
+
+charset_name
+    : string
+    {
+        $$ = $1
+    }
+    | DEFAULT
+    {
+        $$ = ""
+    }
+
+variable
+    : USER_VARIABLE
+    {
+        $$ = &UserVariableExpression{Span: newSpan(yylex, $<tok>1), Name: $1.lit}
+    }
+    | SYSTEM_VARIABLE
+    {
+        $$ = newSystemVariableExpression(newSpan(yylex, $1), $1.lit)
+    }
+    | IDENT
+    {
+        $$ = &SystemVariableExpression{Span: newSpan(yylex, $<tok>1), Name: $1.lit}
+    }
+    | variable_scope IDENT
+    {
+        $$ = &SystemVariableExpression{Span: newSpan(yylex, $<tok>1), Scope: $1, Name: $2.lit}
+    }
+
+variable_scope
+    : GLOBAL
+    {
+        $$ = "GLOBAL"
+    }
+    | SESSION
+    {
+        $$ = "SESSION"
+    }
+    | LOCAL
+    {
+        $$ = "SESSION"
+    }
+    | PERSIST
+    {
+        $$ = "PERSIST"
+    }
+    | PERSIST_ONLY
+    {
+        $$ = "PERSIST_ONLY"
+    }
+
+set_value
+    : expression
+    {
+        $$ = $1
+    }
+    | ON
+    {
+        $$ = &ColumnExpression{Span: newSpan(yylex, $<tok>1), ColumnName: ColumnNameIdentifier{Span: tokenSpan($1, $1), Name: $1.lit}}
+    }
+    | DEFAULT
+    {
+        $$ = &DefaultExpression{Span: newSpan(yylex, $<tok>1)}
+    }
+
+create_definitions
+    : create_definition
+    {
+        $$ = []CreateDefinition{$1}
+    }
+    | create_definitions ',' create_definition
+    {
+        $$ = append($1, $3)
+    }
+
+create_definition
+    : column_name column_definition
+    {
+        $$ = &CreateDefinitionColumn{Span: newSpan(yylex, $<tok>1), ColumnName: $1, ColumnDefinition: $2}
+    }
+    | PRIMARY KEY skipable_index_type '(' index_column_names ')'
+    {
+        $$ = &CreateDefinitionPrimaryIndex{Span: newSpan(yylex, $<tok>1), Columns: $5}
+    }
+    | index_or_key skipable_index_name skipable_index_type '(' index_column_names ')' skipable_index_type
+    {
+        $$ = &CreateDefinitionIndex{Span: newSpan(yylex, $<tok>1), Name: $2, Columns: $5}
+    }
+    | UNIQUE index_or_key skipable_index_name skipable_index_type '(' index_column_names ')'
+    {
+        $$ = &CreateDefinitionUniqueIndex{Span: newSpan(yylex, $<tok>1), Name: $3, Columns: $6}
+    }
+
+skipable_table_options
+    :
+    {
+        $$ = []TableOption{}
+    }
+    | table_options
+    {
+        $$ = $1
+    }
+
+table_options
+    : table_option
+    {
+        setVersionGate(yylex, $1)
+        $$ = []TableOption{$1}
+    }
+    | table_options table_option
+    {
+        setVersionGate(yylex, $2)
+        $$ = append($1, $2)
+    }
+    | table_options ',' table_option
+    {
+        // the comma is a part of the executable comment which encloses
+        // the option such as "/*!50100 , ROW_FORMAT=COMPACT */".
+        setVersionGate(yylex, $3)
+        claimVersionGate(yylex, $<tok>2.pos, $3.End())
+        $$ = append($1, $3)
+    }
+
+database_options
+    :
+    {
+        $$ = nil
+    }
+    | database_options database_option
+    {
+        setVersionGate(yylex, $2)
+        $$ = append($1, $2)
+    }
+
+database_option
+    : skipable_default charset_or_character_set skipable_equal charset_name
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, firstToken($<tok>1, $<tok>2)), Key: "DEFAULT CHARACTER SET", Value: $4}
+    }
+    | skipable_default COLLATE skipable_equal charset_name
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, firstToken($<tok>1, $<tok>2)), Key: "COLLATE", Value: $4}
+    }
+    | skipable_default ENCRYPTION skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, firstToken($<tok>1, $<tok>2)), Key: "ENCRYPTION", Value: $4}
+    }
+
+table_option
+    : ENGINE skipable_equal storage_engine_name
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, $<tok>1), Key: "ENGINE", Value: $3}
+    }
+    | SECONDARY_ENGINE skipable_equal storage_engine_name
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, $<tok>1), Key: "SECONDARY_ENGINE", Value: $3}
+    }
+    | SECONDARY_ENGINE skipable_equal NULL
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, $<tok>1), Key: "SECONDARY_ENGINE", Value: "NULL"}
+    }
+    | AUTO_INCREMENT skipable_equal number
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "AUTO_INCREMENT", Value: $3}
+    }
+    | AUTOEXTEND_SIZE skipable_equal size
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "AUTOEXTEND_SIZE", Value: $3}
+    }
+    | AVG_ROW_LENGTH skipable_equal number
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "AVG_ROW_LENGTH", Value: $3}
+    }
+    | CHECKSUM skipable_equal number
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "CHECKSUM", Value: $3}
+    }
+    | DELAY_KEY_WRITE skipable_equal number
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "DELAY_KEY_WRITE", Value: $3}
+    }
+    | KEY_BLOCK_SIZE skipable_equal number
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "KEY_BLOCK_SIZE", Value: $3}
+    }
+    | MAX_ROWS skipable_equal number
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "MAX_ROWS", Value: $3}
+    }
+    | MIN_ROWS skipable_equal number
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "MIN_ROWS", Value: $3}
+    }
+    | STATS_SAMPLE_PAGES skipable_equal number
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "STATS_SAMPLE_PAGES", Value: $3}
+    }
+    | PACK_KEYS skipable_equal number_or_default
+    {
+        $$ = newTableOptionNumberOrDefault(newSpan(yylex, $<tok>1), "PACK_KEYS", $3)
+    }
+    | STATS_AUTO_RECALC skipable_equal number_or_default
+    {
+        $$ = newTableOptionNumberOrDefault(newSpan(yylex, $<tok>1), "STATS_AUTO_RECALC", $3)
+    }
+    | STATS_PERSISTENT skipable_equal number_or_default
+    {
+        $$ = newTableOptionNumberOrDefault(newSpan(yylex, $<tok>1), "STATS_PERSISTENT", $3)
+    }
+    | skipable_default charset_or_character_set skipable_equal charset_name
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, firstToken($<tok>1, $<tok>2)), Key: "DEFAULT CHARACTER SET", Value: $4}
+    }
+    | skipable_default COLLATE skipable_equal charset_name
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, firstToken($<tok>1, $<tok>2)), Key: "COLLATE", Value: $4}
+    }
+    | COMMENT skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "COMMENT", Value: $3}
+    }
+    | COMPRESSION skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "COMPRESSION", Value: $3}
+    }
+    | CONNECTION skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "CONNECTION", Value: $3}
+    }
+    | DATA DIRECTORY skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "DATA DIRECTORY", Value: $4}
+    }
+    | INDEX DIRECTORY skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "INDEX DIRECTORY", Value: $4}
+    }
+    | ENCRYPTION skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "ENCRYPTION", Value: $3}
+    }
+    | ENGINE_ATTRIBUTE skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "ENGINE_ATTRIBUTE", Value: $3}
+    }
+    | SECONDARY_ENGINE_ATTRIBUTE skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "SECONDARY_ENGINE_ATTRIBUTE", Value: $3}
+    }
+    | PASSWORD skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "PASSWORD", Value: $3}
+    }
+    | INSERT_METHOD skipable_equal insert_method
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, $<tok>1), Key: "INSERT_METHOD", Value: $3}
+    }
+    | ROW_FORMAT skipable_equal row_format
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, $<tok>1), Key: "ROW_FORMAT", Value: $3}
+    }
+    | TABLESPACE skipable_equal name
+    {
+        $$ = &TableOptionTablespace{Span: newSpan(yylex, $<tok>1), Name: $3}
+    }
+    | TABLESPACE skipable_equal name STORAGE DISK
+    {
+        $$ = &TableOptionTablespace{Span: newSpan(yylex, $<tok>1), Name: $3, Storage: "DISK"}
+    }
+    | TABLESPACE skipable_equal name STORAGE MEMORY
+    {
+        $$ = &TableOptionTablespace{Span: newSpan(yylex, $<tok>1), Name: $3, Storage: "MEMORY"}
+    }
+    | UNION skipable_equal '(' table_name_list ')'
+    {
+        $$ = &TableOptionUnion{Span: newSpan(yylex, $<tok>1), TableNames: $4}
+    }
+
+// table_name_list keeps the order of tables unlike table_names.
+table_name_list
+    : table_name
+    {
+        $$ = []TableNameIdentifier{$1}
+    }
+    | table_name_list ',' table_name
+    {
+        $$ = append($1, $3)
+    }
+
+number
+    : NUMBER
+    {
+        num, err := strconv.ParseUint($1.lit, 10, 64)
+        if err != nil {
+            invalidValue(yylex, $1, "number out of range")
+            return 1
+        }
+        $$ = num
+    }
+
+number_literal
+    : NUMBER
+    {
+        $$ = &NumberExpression{Span: newSpan(yylex, $<tok>1), Value: $1.lit, Type: NUMBER_TYPE_INTEGER}
+    }
+    | DECIMAL_NUMBER
+    {
+        $$ = &NumberExpression{Span: newSpan(yylex, $<tok>1), Value: $1.lit, Type: NUMBER_TYPE_DECIMAL}
+    }
+    | FLOAT_NUMBER
+    {
+        $$ = &NumberExpression{Span: newSpan(yylex, $<tok>1), Value: $1.lit, Type: NUMBER_TYPE_FLOAT}
+    }
+    | HEX_NUMBER
+    {
+        $$ = &NumberExpression{Span: newSpan(yylex, $<tok>1), Value: $1.lit, Type: NUMBER_TYPE_HEX}
+    }
+    | BIT_NUMBER
+    {
+        $$ = &NumberExpression{Span: newSpan(yylex, $<tok>1), Value: $1.lit, Type: NUMBER_TYPE_BIT}
+    }
+
+// number with sign such as DEFAULT -1, where expressions are not allowed.
+signed_number_literal
+    : number_literal
+    {
+        $$ = $1
+    }
+    | '+' number_literal
+    {
+        if $2.Type == NUMBER_TYPE_HEX || $2.Type == NUMBER_TYPE_BIT {
+            return 1
+        }
+        $2.Span = newSpan(yylex, $<tok>1)
+        $$ = $2
+    }
+    | '-' number_literal
+    {
+        if $2.Type == NUMBER_TYPE_HEX || $2.Type == NUMBER_TYPE_BIT {
+            return 1
+        }
+        $2.Span = newSpan(yylex, $<tok>1)
+        $2.Value = "-" + $2.Value
+        $$ = $2
+    }
+
+// -1 means DEFAULT.
+number_or_default
+    : NUMBER
+    {
+        num, err := strconv.ParseInt($1.lit, 10, 64)
+        if err != nil {
+            invalidValue(yylex, $1, "number out of range")
+            return 1
+        }
+        $$ = num
+    }
+    | DEFAULT
+    {
+        $$ = -1
+    }
+
+quoted_string
+    : '\'' RAW '\''
+    {
+        $$ = $2.lit
+    }
+    | '"' RAW '"'
+    {
+        $$ = $2.lit
+    }
+
+insert_method
+    : NO
+    {
+        $$ = "NO"
+    }
+    | FIRST
+    {
+        $$ = "FIRST"
+    }
+    | LAST
+    {
+        $$ = "LAST"
+    }
+
+row_format
+    : DEFAULT
+    {
+        $$ = "DEFAULT"
+    }
+    | DYNAMIC
+    {
+        $$ = "DYNAMIC"
+    }
+    | FIXED
+    {
+        $$ = "FIXED"
+    }
+    | COMPRESSED
+    {
+        $$ = "COMPRESSED"
+    }
+    | REDUNDANT
+    {
+        $$ = "REDUNDANT"
+    }
+    | COMPACT
+    {
+        $$ = "COMPACT"
+    }
+
+if_not_exists
+    :
+    {
+        $$ = false
+    }
+    | IF NOT EXISTS
+    {
+        $$ = true
+    }
+
+if_exists
+    :
+    {
+        $$ = false
+    }
+    | IF EXISTS
+    {
+        $$ = true
+    }
+
+user_specifications
+    : user_specification
+    {
+        $$ = []UserSpecification{$1}
+    }
+    | user_specifications ',' user_specification
+    {
+        $$ = append($1, $3)
+    }
+
+user_specification
+    : account_name
+    {
+        $$ = UserSpecification{Span: newSpan(yylex, $<tok>1), Account: $1}
+    }
+    | account_name auth_option
+    {
+        $$ = UserSpecification{Span: newSpan(yylex, $<tok>1), Account: $1, Auth: $2}
+    }
+
+auth_option
+    : IDENTIFIED BY quoted_string
+    {
+        $$ = &AuthOption{Span: newSpan(yylex, $<tok>1), Password: $3}
+    }
+    | IDENTIFIED BY RANDOM PASSWORD
+    {
+        $$ = &AuthOption{Span: newSpan(yylex, $<tok>1), RandomPassword: true}
+    }
+    | IDENTIFIED WITH name_or_string
+    {
+        $$ = &AuthOption{Span: newSpan(yylex, $<tok>1), Plugin: $3}
+    }
+    | IDENTIFIED WITH name_or_string BY quoted_string
+    {
+        $$ = &AuthOption{Span: newSpan(yylex, $<tok>1), Plugin: $3, Password: $5}
+    }
+    | IDENTIFIED WITH name_or_string BY RANDOM PASSWORD
+    {
+        $$ = &AuthOption{Span: newSpan(yylex, $<tok>1), Plugin: $3, RandomPassword: true}
+    }
+    | IDENTIFIED WITH name_or_string AS quoted_string
+    {
+        $$ = &AuthOption{Span: newSpan(yylex, $<tok>1), Plugin: $3, AuthString: $5}
+    }
+
+name_or_string
+    : name
+    {
+        $$ = $1
+    }
+    | quoted_string
+    {
+        $$ = $1
+    }
+
+default_role_option
+    :
+    {
+        $$ = nil
+    }
+    | DEFAULT ROLE account_names
+    {
+        $$ = $3
+    }
+
+account_lock_option
+    :
+    {
+        $$ = ""
+    }
+    | ACCOUNT LOCK
+    {
+        $$ = "LOCK"
+    }
+    | ACCOUNT UNLOCK
+    {
+        $$ = "UNLOCK"
+    }
+
+account_names
+    : account_name
+    {
+        $$ = []AccountNameIdentifier{$1}
+    }
+    | account_names ',' account_name
+    {
+        $$ = append($1, $3)
+    }
+
+account_name
+    : account_name_part
+    {
+        $$ = AccountNameIdentifier{Span: newSpan(yylex, $<tok>1), User: $1}
+    }
+    | account_name_part USER_VARIABLE
+    {
+        $$ = AccountNameIdentifier{Span: newSpan(yylex, $<tok>1), User: $1, Host: $2.lit}
+    }
+    | account_name_part '@' account_name_part
+    {
+        $$ = AccountNameIdentifier{Span: newSpan(yylex, $<tok>1), User: $1, Host: $3}
+    }
+    | CURRENT_USER
+    {
+        $$ = AccountNameIdentifier{Span: newSpan(yylex, $<tok>1), CurrentUser: true}
+    }
+    | CURRENT_USER '(' ')'
+    {
+        $$ = AccountNameIdentifier{Span: newSpan(yylex, $<tok>1), CurrentUser: true}
+    }
+
+account_name_part
+    : name
+    {
+        $$ = $1
+    }
+    | quoted_string
+    {
+        $$ = $1
+    }
+
+with_grant_option
+    :
+    {
+        $$ = false
+    }
+    | WITH GRANT OPTION
+    {
+        $$ = true
+    }
+
+with_admin_option
+    :
+    {
+        $$ = false
+    }
+    | WITH ADMIN OPTION
+    {
+        $$ = true
+    }
+
+// GRANT and REVOKE can't tell roles from privileges until ON or TO/FROM
+// appears, so the items are kept as both.
+role_or_privileges
+    : role_or_privilege
+    {
+        $$ = []roleOrPrivilege{$1}
+    }
+    | role_or_privileges ',' role_or_privilege
+    {
+        $$ = append($1, $3)
+    }
+
+role_or_privilege
+    : ident
+    {
+        $$ = roleOrPrivilege{token: $<tok>1, role: &AccountNameIdentifier{Span: newSpan(yylex, $<tok>1), User: $1.lit}, privilege: &Privilege{Span: newSpan(yylex, $<tok>1), Type: strings.ToUpper($1.lit)}}
+    }
+    | ident '(' index_column_names ')'
+    {
+        $$ = roleOrPrivilege{token: $<tok>1, privilege: &Privilege{Span: newSpan(yylex, $<tok>1), Type: strings.ToUpper($1.lit), Columns: $3}}
+    }
+    | ident USER_VARIABLE
+    {
+        $$ = roleOrPrivilege{token: $<tok>1, role: &AccountNameIdentifier{Span: newSpan(yylex, $<tok>1), User: $1.lit, Host: $2.lit}}
+    }
+    | ident '@' account_name_part
+    {
+        $$ = roleOrPrivilege{token: $<tok>1, role: &AccountNameIdentifier{Span: newSpan(yylex, $<tok>1), User: $1.lit, Host: $3}}
+    }
+    | quoted_role_name
+    {
+        $$ = roleOrPrivilege{token: $<tok>1, role: &AccountNameIdentifier{Span: newSpan(yylex, $<tok>1), User: $1}}
+    }
+    | quoted_role_name USER_VARIABLE
+    {
+        $$ = roleOrPrivilege{token: $<tok>1, role: &AccountNameIdentifier{Span: newSpan(yylex, $<tok>1), User: $1, Host: $2.lit}}
+    }
+    | quoted_role_name '@' account_name_part
+    {
+        $$ = roleOrPrivilege{token: $<tok>1, role: &AccountNameIdentifier{Span: newSpan(yylex, $<tok>1), User: $1, Host: $3}}
+    }
+    | privilege_type
+    {
+        $$ = roleOrPrivilege{token: $<tok>1, privilege: &Privilege{Span: newSpan(yylex, $<tok>1), Type: $1}}
+    }
+    | privilege_type '(' index_column_names ')'
+    {
+        $$ = roleOrPrivilege{token: $<tok>1, privilege: &Privilege{Span: newSpan(yylex, $<tok>1), Type: $1, Columns: $3}}
+    }
+
+quoted_role_name
+    : '`' RAW '`'
+    {
+        $$ = $2.lit
+    }
+    | quoted_string
+    {
+        $$ = $1
+    }
+
+// privileges which are not identifiers. The others such as RELOAD or
+// BACKUP_ADMIN are scanned as ident.
+privilege_type
+    : ALL
+    {
+        $$ = "ALL"
+    }
+    | ALL PRIVILEGES
+    {
+        $$ = "ALL"
+    }
+    | ALTER
+    {
+        $$ = "ALTER"
+    }
+    | ALTER ROUTINE
+    {
+        $$ = "ALTER ROUTINE"
+    }
+    | CREATE
+    {
+        $$ = "CREATE"
+    }
+    | CREATE ROLE
+    {
+        $$ = "CREATE ROLE"
+    }
+    | CREATE ROUTINE
+    {
+        $$ = "CREATE ROUTINE"
+    }
+    | CREATE TABLESPACE
+    {
+        $$ = "CREATE TABLESPACE"
+    }
+    | CREATE TEMPORARY TABLES
+    {
+        $$ = "CREATE TEMPORARY TABLES"
+    }
+    | CREATE USER
+    {
+        $$ = "CREATE USER"
+    }
+    | CREATE VIEW
+    {
+        $$ = "CREATE VIEW"
+    }
+    | DELETE
+    {
+        $$ = "DELETE"
+    }
+    | DROP
+    {
+        $$ = "DROP"
+    }
+    | DROP ROLE
+    {
+        $$ = "DROP ROLE"
+    }
+    | GRANT OPTION
+    {
+        $$ = "GRANT OPTION"
+    }
+    | INDEX
+    {
+        $$ = "INDEX"
+    }
+    | INSERT
+    {
+        $$ = "INSERT"
+    }
+    | LOCK TABLES
+    {
+        $$ = "LOCK TABLES"
+    }
+    | REFERENCES
+    {
+        $$ = "REFERENCES"
+    }
+    | REPLICATION CLIENT
+    {
+        $$ = "REPLICATION CLIENT"
+    }
+    | REPLICATION SLAVE
+    {
+        $$ = "REPLICATION SLAVE"
+    }
+    | SELECT
+    {
+        $$ = "SELECT"
+    }
+    | SHOW DATABASES
+    {
+        $$ = "SHOW DATABASES"
+    }
+    | SHOW VIEW
+    {
+        $$ = "SHOW VIEW"
+    }
+    | TRIGGER
+    {
+        $$ = "TRIGGER"
+    }
+    | UPDATE
+    {
+        $$ = "UPDATE"
+    }
+    | USAGE
+    {
+        $$ = "USAGE"
+    }
+
+privilege_level
+    : privilege_level_name
+    {
+        $$ = $1
+    }
+    | TABLE privilege_level_name
+    {
+        $$ = $2
+        $$.Span = newSpan(yylex, $<tok>1)
+        $$.ObjectType = "TABLE"
+    }
+    | FUNCTION privilege_level_name
+    {
+        $$ = $2
+        $$.Span = newSpan(yylex, $<tok>1)
+        $$.ObjectType = "FUNCTION"
+    }
+    | PROCEDURE privilege_level_name
+    {
+        $$ = $2
+        $$.Span = newSpan(yylex, $<tok>1)
+        $$.ObjectType = "PROCEDURE"
+    }
+
+privilege_level_name
+    : '*'
+    {
+        $$ = PrivilegeLevel{Span: newSpan(yylex, $<tok>1), Table: "*"}
+    }
+    | '*' '.' '*'
+    {
+        $$ = PrivilegeLevel{Span: newSpan(yylex, $<tok>1), Database: "*", Table: "*"}
+    }
+    | name '.' '*'
+    {
+        $$ = PrivilegeLevel{Span: newSpan(yylex, $<tok>1), Database: $1, Table: "*"}
+    }
+    | name '.' name
+    {
+        $$ = PrivilegeLevel{Span: newSpan(yylex, $<tok>1), Database: $1, Table: $3}
+    }
+    | name
+    {
+        $$ = PrivilegeLevel{Span: newSpan(yylex, $<tok>1), Table: $1}
+    }
+
+undo
+    :
+    {
+        $$ = false
+    }
+    | UNDO
+    {
+        $$ = true
+    }
+
+tablespace_datafile
+    :
+    {
+        $$ = ""
+    }
+    | ADD DATAFILE quoted_string
+    {
+        $$ = $3
+    }
+
+tablespace_logfile_group
+    :
+    {
+        $$ = ""
+    }
+    | USE LOGFILE GROUP name
+    {
+        $$ = $4
+    }
+
+tablespace_options
+    :
+    {
+        $$ = []TableOption{}
+    }
+    | tablespace_option_list
+    {
+        $$ = $1
+    }
+
+tablespace_option_list
+    : tablespace_option
+    {
+        $$ = []TableOption{$1}
+    }
+    | tablespace_option_list tablespace_option
+    {
+        $$ = append($1, $2)
+    }
+    | tablespace_option_list ',' tablespace_option
+    {
+        $$ = append($1, $3)
+    }
+
+tablespace_option
+    : INITIAL_SIZE skipable_equal size
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "INITIAL_SIZE", Value: $3}
+    }
+    | AUTOEXTEND_SIZE skipable_equal size
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "AUTOEXTEND_SIZE", Value: $3}
+    }
+    | MAX_SIZE skipable_equal size
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "MAX_SIZE", Value: $3}
+    }
+    | EXTENT_SIZE skipable_equal size
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "EXTENT_SIZE", Value: $3}
+    }
+    | FILE_BLOCK_SIZE skipable_equal size
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "FILE_BLOCK_SIZE", Value: $3}
+    }
+    | UNDO_BUFFER_SIZE skipable_equal size
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "UNDO_BUFFER_SIZE", Value: $3}
+    }
+    | REDO_BUFFER_SIZE skipable_equal size
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "REDO_BUFFER_SIZE", Value: $3}
+    }
+    | NODEGROUP skipable_equal number
+    {
+        $$ = &TableOptionNumber{Span: newSpan(yylex, $<tok>1), Key: "NODEGROUP", Value: $3}
+    }
+    | COMMENT skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "COMMENT", Value: $3}
+    }
+    | ENCRYPTION skipable_equal quoted_string
+    {
+        $$ = &TableOptionString{Span: newSpan(yylex, $<tok>1), Key: "ENCRYPTION", Value: $3}
+    }
+    | ENGINE skipable_equal storage_engine_name
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, $<tok>1), Key: "ENGINE", Value: $3}
+    }
+    | STORAGE ENGINE skipable_equal storage_engine_name
+    {
+        $$ = &TableOptionName{Span: newSpan(yylex, $<tok>1), Key: "ENGINE", Value: $4}
+    }
+    | WAIT
+    {
+        $$ = &TableOptionFlag{Span: newSpan(yylex, $<tok>1), Key: "WAIT"}
+    }
+    | NO_WAIT
+    {
+        $$ = &TableOptionFlag{Span: newSpan(yylex, $<tok>1), Key: "NO_WAIT"}
+    }
+
+// size such as 1048576, 16M or 1G.
+size
+    : number
+    {
+        $$ = $1
+    }
+    | NUMBER IDENT
+    {
+        size, ok := parseSize($1.lit, $2.lit)
+        if !ok {
+            invalidValue(yylex, $1, "invalid size")
+            return 1
+        }
+        $$ = size
+    }
+    | IDENT
+    {
+        // "16M" is scanned as an identifier since it starts with digits.
+        if len($1.lit) < 2 {
+            return 1
+        }
+        size, ok := parseSize($1.lit[:len($1.lit)-1], $1.lit[len($1.lit)-1:])
+        if !ok {
+            invalidValue(yylex, $1, "invalid size")
+            return 1
+        }
+        $$ = size
+    }
+
+server_options
+    : server_option
+    {
+        $$ = []ServerOption{$1}
+    }
+    | server_options ',' server_option
+    {
+        $$ = append($1, $3)
+    }
+
+server_option
+    : HOST quoted_string
+    {
+        $$ = ServerOption{Span: newSpan(yylex, $<tok>1), Key: "HOST", Value: $2}
+    }
+    | DATABASE quoted_string
+    {
+        $$ = ServerOption{Span: newSpan(yylex, $<tok>1), Key: "DATABASE", Value: $2}
+    }
+    | USER quoted_string
+    {
+        $$ = ServerOption{Span: newSpan(yylex, $<tok>1), Key: "USER", Value: $2}
+    }
+    | PASSWORD quoted_string
+    {
+        $$ = ServerOption{Span: newSpan(yylex, $<tok>1), Key: "PASSWORD", Value: $2}
+    }
+    | SOCKET quoted_string
+    {
+        $$ = ServerOption{Span: newSpan(yylex, $<tok>1), Key: "SOCKET", Value: $2}
+    }
+    | OWNER quoted_string
+    {
+        $$ = ServerOption{Span: newSpan(yylex, $<tok>1), Key: "OWNER", Value: $2}
+    }
+    | PORT NUMBER
+    {
+        $$ = ServerOption{Span: newSpan(yylex, $<tok>1), Key: "PORT", Value: $2.lit}
+    }
+
+transaction_characteristics
+    : transaction_characteristic
+    {
+        $$ = []string{$1}
+    }
+    | transaction_characteristics ',' transaction_characteristic
+    {
+        $$ = append($1, $3)
+    }
+
+transaction_characteristic
+    : WITH CONSISTENT SNAPSHOT
+    {
+        $$ = "WITH CONSISTENT SNAPSHOT"
+    }
+    | READ WRITE
+    {
+        $$ = "READ WRITE"
+    }
+    | READ ONLY
+    {
+        $$ = "READ ONLY"
+    }
+
+skipable_work
+    :
+    | WORK
+
+completion_chain
+    :
+    {
+        $$ = ""
+    }
+    | AND CHAIN
+    {
+        $$ = "CHAIN"
+    }
+    | AND NO CHAIN
+    {
+        $$ = "NO CHAIN"
+    }
+
+completion_release
+    :
+    {
+        $$ = ""
+    }
+    | RELEASE
+    {
+        $$ = "RELEASE"
+    }
+    | NO RELEASE
+    {
+        $$ = "NO RELEASE"
+    }
+
+table_or_tables
+    : TABLE
+    | TABLES
+
+table_locks
+    : table_lock
+    {
+        $$ = []TableLock{$1}
+    }
+    | table_locks ',' table_lock
+    {
+        $$ = append($1, $3)
+    }
+
+table_lock
+    : table_name lock_type
+    {
+        $$ = TableLock{Span: newSpan(yylex, $<tok>1), TableName: $1, Type: $2}
+    }
+    | table_name name lock_type
+    {
+        $$ = TableLock{Span: newSpan(yylex, $<tok>1), TableName: $1, Alias: $2, Type: $3}
+    }
+    | table_name AS name lock_type
+    {
+        $$ = TableLock{Span: newSpan(yylex, $<tok>1), TableName: $1, Alias: $3, Type: $4}
+    }
+
+lock_type
+    : READ
+    {
+        $$ = "READ"
+    }
+    | READ LOCAL
+    {
+        $$ = "READ LOCAL"
+    }
+    | WRITE
+    {
+        $$ = "WRITE"
+    }
+    | LOW_PRIORITY WRITE
+    {
+        $$ = "LOW_PRIORITY WRITE"
+    }
+
+no_write_to_binlog
+    :
+    {
+        $$ = false
+    }
+    | NO_WRITE_TO_BINLOG
+    {
+        $$ = true
+    }
+    | LOCAL
+    {
+        $$ = true
+    }
+
+check_options
+    :
+    {
+        $$ = nil
+    }
+    | check_options check_option
+    {
+        $$ = append($1, $2)
+    }
+
+check_option
+    : FOR UPGRADE
+    {
+        $$ = "FOR UPGRADE"
+    }
+    | QUICK
+    {
+        $$ = "QUICK"
+    }
+    | FAST
+    {
+        $$ = "FAST"
+    }
+    | MEDIUM
+    {
+        $$ = "MEDIUM"
+    }
+    | EXTENDED
+    {
+        $$ = "EXTENDED"
+    }
+    | CHANGED
+    {
+        $$ = "CHANGED"
+    }
+
+flush_options
+    : flush_option
+    {
+        $$ = []string{$1}
+    }
+    | flush_options ',' flush_option
+    {
+        $$ = append($1, $3)
+    }
+
+flush_option
+    : BINARY LOGS
+    {
+        $$ = "BINARY LOGS"
+    }
+    | ENGINE LOGS
+    {
+        $$ = "ENGINE LOGS"
+    }
+    | ERROR LOGS
+    {
+        $$ = "ERROR LOGS"
+    }
+    | GENERAL LOGS
+    {
+        $$ = "GENERAL LOGS"
+    }
+    | RELAY LOGS
+    {
+        $$ = "RELAY LOGS"
+    }
+    | SLOW LOGS
+    {
+        $$ = "SLOW LOGS"
+    }
+    | LOGS
+    {
+        $$ = "LOGS"
+    }
+    | HOSTS
+    {
+        $$ = "HOSTS"
+    }
+    | PRIVILEGES
+    {
+        $$ = "PRIVILEGES"
+    }
+    | OPTIMIZER_COSTS
+    {
+        $$ = "OPTIMIZER_COSTS"
+    }
+    | STATUS
+    {
+        $$ = "STATUS"
+    }
+    | USER_RESOURCES
+    {
+        $$ = "USER_RESOURCES"
+    }
+
+flush_tables_lock
+    :
+    {
+        $$ = ""
+    }
+    | WITH READ LOCK
+    {
+        $$ = "WITH READ LOCK"
+    }
+    | FOR EXPORT
+    {
+        $$ = "FOR EXPORT"
+    }
+
+query_expression
+    : query_expression_body order_by_clause limit_clause select_lock
+    {
+        query, ok := newQueryExpression(newSpan(yylex, $<tok>1), nil, $1, $2, $3, $4)
+        if !ok {
+            return 1
+        }
+        $$ = query
+    }
+    | with_clause query_expression_body order_by_clause limit_clause select_lock
+    {
+        query, ok := newQueryExpression(newSpan(yylex, $<tok>1), $1, $2, $3, $4, $5)
+        if !ok {
+            return 1
+        }
+        $$ = query
+    }
+
+query_expression_body
+    : query_term
+    {
+        $$ = $1
+    }
+    | query_expression_body UNION set_quantifier query_term
+    {
+        $$ = &SetOperationStatement{Span: newSpan(yylex, $<tok>1), Left: $1, Operator: "UNION", All: $3, Right: $4}
+    }
+    | query_expression_body EXCEPT set_quantifier query_term
+    {
+        $$ = &SetOperationStatement{Span: newSpan(yylex, $<tok>1), Left: $1, Operator: "EXCEPT", All: $3, Right: $4}
+    }
+    | query_parens UNION set_quantifier query_term
+    {
+        $$ = &SetOperationStatement{Span: newSpan(yylex, $<tok>1), Left: $1, Operator: "UNION", All: $3, Right: $4}
+    }
+    | query_parens EXCEPT set_quantifier query_term
+    {
+        $$ = &SetOperationStatement{Span: newSpan(yylex, $<tok>1), Left: $1, Operator: "EXCEPT", All: $3, Right: $4}
+    }
+    | query_expression_body UNION set_quantifier query_parens
+    {
+        $$ = &SetOperationStatement{Span: newSpan(yylex, $<tok>1), Left: $1, Operator: "UNION", All: $3, Right: $4}
+    }
+    | query_expression_body EXCEPT set_quantifier query_parens
+    {
+        $$ = &SetOperationStatement{Span: newSpan(yylex, $<tok>1), Left: $1, Operator: "EXCEPT", All: $3, Right: $4}
+    }
+    | query_parens UNION set_quantifier query_parens
+    {
+        $$ = &SetOperationStatement{Span: newSpan(yylex, $<tok>1), Left: $1, Operator: "UNION", All: $3, Right: $4}
+    }
+    | query_parens EXCEPT set_quantifier query_parens
+    {
+        $$ = &SetOperationStatement{Span: newSpan(yylex, $<tok>1), Left: $1, Operator: "EXCEPT", All: $3, Right: $4}
+    }
+
+query_term
+    : select_statement
+    {
+        $$ = $1
+    }
+    | query_term INTERSECT set_quantifier query_primary
+    {
+        $$ = &SetOperationStatement{Span: newSpan(yylex, $<tok>1), Left: $1, Operator: "INTERSECT", All: $3, Right: $4}
+    }
+    | query_parens INTERSECT set_quantifier query_primary
+    {
+        $$ = &SetOperationStatement{Span: newSpan(yylex, $<tok>1), Left: $1, Operator: "INTERSECT", All: $3, Right: $4}
+    }
+
+query_primary
+    : select_statement
+    {
+        $$ = $1
+    }
+    | query_parens
+    {
+        $$ = $1
+    }
+
+query_parens
+    : '(' query_expression ')'
+    {
+        $$ = &ParenQueryExpression{Span: newSpan(yylex, $<tok>1), Query: $2}
+    }
+
+set_quantifier
+    :
+    {
+        $$ = false
+    }
+    | ALL
+    {
+        $$ = true
+    }
+    | DISTINCT
+    {
+        $$ = false
+    }
+
+with_clause
+    : WITH common_table_expressions
+    {
+        $$ = &WithClause{Span: newSpan(yylex, $<tok>1), CommonTableExpressions: $2}
+    }
+    | WITH RECURSIVE common_table_expressions
+    {
+        $$ = &WithClause{Span: newSpan(yylex, $<tok>1), Recursive: true, CommonTableExpressions: $3}
+    }
+
+common_table_expressions
+    : common_table_expression
+    {
+        $$ = []CommonTableExpression{$1}
+    }
+    | common_table_expressions ',' common_table_expression
+    {
+        $$ = append($1, $3)
+    }
+
+common_table_expression
+    : name cte_columns AS query_parens
+    {
+        $$ = CommonTableExpression{Span: newSpan(yylex, $<tok>1), Name: $1, Columns: $2, Query: $4.Query}
+    }
+
+cte_columns
+    :
+    {
+        $$ = nil
+    }
+    | '(' index_column_names ')'
+    {
+        $$ = $2
+    }
+
+select_statement
+    : SELECT optimizer_hints select_options select_fields from_clause where_clause group_by_clause having_clause window_clause
+    {
+        $$ = &SelectStatement{Span: newSpan(yylex, $<tok>1), Hints: $2, Distinct: $3, Fields: $4, From: $5, Where: $6, GroupBy: $7, Having: $8, Windows: $9}
+    }
+
+window_clause
+    :
+    {
+        $$ = nil
+    }
+    | WINDOW window_definitions
+    {
+        $$ = $2
+    }
+
+window_definitions
+    : window_definition
+    {
+        $$ = []WindowDefinition{$1}
+    }
+    | window_definitions ',' window_definition
+    {
+        $$ = append($1, $3)
+    }
+
+window_definition
+    : name AS window_specification
+    {
+        $$ = WindowDefinition{Span: newSpan(yylex, $<tok>1), Name: $1, Window: $3}
+    }
+
+window_specification
+    : '(' window_name partition_by_clause order_by_clause window_frame ')'
+    {
+        $$ = &WindowSpecification{Span: newSpan(yylex, $<tok>1), Name: $2, PartitionBy: $3, OrderBy: $4, Frame: $5}
+    }
+
+window_name
+    :
+    {
+        $$ = ""
+    }
+    | name
+    {
+        $$ = $1
+    }
+
+partition_by_clause
+    :
+    {
+        $$ = nil
+    }
+    | PARTITION BY expressions
+    {
+        $$ = $3
+    }
+
+window_frame
+    :
+    {
+        $$ = nil
+    }
+    | window_frame_unit window_frame_bound
+    {
+        $$ = &WindowFrame{Span: newSpan(yylex, $<tok>1), Unit: $1, Start: $2}
+    }
+    | window_frame_unit BETWEEN window_frame_bound AND window_frame_bound
+    {
+        end := $5
+        $$ = &WindowFrame{Span: newSpan(yylex, $<tok>1), Unit: $1, Start: $3, End: &end}
+    }
+
+window_frame_unit
+    : ROWS
+    {
+        $$ = "ROWS"
+    }
+    | RANGE
+    {
+        $$ = "RANGE"
+    }
+
+window_frame_bound
+    : UNBOUNDED PRECEDING
+    {
+        $$ = WindowFrameBound{Span: newSpan(yylex, $<tok>1), Type: "UNBOUNDED PRECEDING"}
+    }
+    | UNBOUNDED FOLLOWING
+    {
+        $$ = WindowFrameBound{Span: newSpan(yylex, $<tok>1), Type: "UNBOUNDED FOLLOWING"}
+    }
+    | CURRENT ROW
+    {
+        $$ = WindowFrameBound{Span: newSpan(yylex, $<tok>1), Type: "CURRENT ROW"}
+    }
+    | bit_expression PRECEDING
+    {
+        $$ = WindowFrameBound{Span: newSpan(yylex, $<tok>1), Type: "PRECEDING", Expression: $1}
+    }
+    | bit_expression FOLLOWING
+    {
+        $$ = WindowFrameBound{Span: newSpan(yylex, $<tok>1), Type: "FOLLOWING", Expression: $1}
+    }
+
+optimizer_hints
+    :
+    {
+        $$ = ""
+    }
+    | OPTIMIZER_HINT
+    {
+        $$ = $1.lit
+    }
+
+select_options
+    :
+    {
+        $$ = false
+    }
+    | ALL
+    {
+        $$ = false
+    }
+    | DISTINCT
+    {
+        $$ = true
+    }
+
+select_fields
+    : select_field
+    {
+        $$ = []SelectField{$1}
+    }
+    | select_fields ',' select_field
+    {
+        $$ = append($1, $3)
+    }
+
+select_field
+    : '*'
+    {
+        $$ = SelectField{Span: newSpan(yylex, $<tok>1), Expression: &StarExpression{Span: newSpan(yylex, $<tok>1)}}
+    }
+    | name '.' '*'
+    {
+        $$ = SelectField{Span: newSpan(yylex, $<tok>1), Expression: &StarExpression{Span: newSpan(yylex, $<tok>1), TableName: TableNameIdentifier{Span: tokenSpan($<tok>1, $<tok>1), Name: $1}}}
+    }
+    | name '.' name '.' '*'
+    {
+        $$ = SelectField{Span: newSpan(yylex, $<tok>1), Expression: &StarExpression{Span: newSpan(yylex, $<tok>1), TableName: TableNameIdentifier{Span: tokenSpan($<tok>1, $<tok>3), Database: $1, Name: $3}}}
+    }
+    | expression
+    {
+        $$ = SelectField{Span: newSpan(yylex, $<tok>1), Expression: $1}
+    }
+    | expression alias
+    {
+        $$ = SelectField{Span: newSpan(yylex, $<tok>1), Expression: $1, Alias: $2}
+    }
+
+alias
+    : name
+    {
+        $$ = $1
+    }
+    | AS name
+    {
+        $$ = $2
+    }
+    | quoted_string
+    {
+        $$ = $1
+    }
+    | AS quoted_string
+    {
+        $$ = $2
+    }
+
+from_clause
+    :
+    {
+        $$ = nil
+    }
+    | FROM DUAL
+    {
+        $$ = nil
+    }
+    | FROM table_references
+    {
+        $$ = $2
+    }
+
+table_references
+    : table_reference
+    {
+        $$ = []TableReference{$1}
+    }
+    | table_references ',' table_reference
+    {
+        $$ = append($1, $3)
+    }
+
+table_reference
+    : table_factor
+    {
+        $$ = $1
+    }
+    | table_reference join_type table_factor %prec JOIN_WITHOUT_CONDITION
+    {
+        $$ = &TableReferenceJoin{Span: newSpan(yylex, $<tok>1), Left: $1, Type: $2, Right: $3}
+    }
+    | table_reference join_type table_factor ON expression
+    {
+        $$ = &TableReferenceJoin{Span: newSpan(yylex, $<tok>1), Left: $1, Type: $2, Right: $3, On: $5}
+    }
+    | table_reference join_type table_factor USING '(' index_column_names ')'
+    {
+        $$ = &TableReferenceJoin{Span: newSpan(yylex, $<tok>1), Left: $1, Type: $2, Right: $3, Using: $6}
+    }
+
+join_type
+    : JOIN
+    {
+        $$ = "JOIN"
+    }
+    | INNER JOIN
+    {
+        $$ = "JOIN"
+    }
+    | CROSS JOIN
+    {
+        $$ = "CROSS JOIN"
+    }
+    | STRAIGHT_JOIN
+    {
+        $$ = "STRAIGHT_JOIN"
+    }
+    | LEFT skipable_outer JOIN
+    {
+        $$ = "LEFT JOIN"
+    }
+    | RIGHT skipable_outer JOIN
+    {
+        $$ = "RIGHT JOIN"
+    }
+    | NATURAL JOIN
+    {
+        $$ = "NATURAL JOIN"
+    }
+    | NATURAL LEFT skipable_outer JOIN
+    {
+        $$ = "NATURAL LEFT JOIN"
+    }
+    | NATURAL RIGHT skipable_outer JOIN
+    {
+        $$ = "NATURAL RIGHT JOIN"
+    }
+
+skipable_outer
+    :
+    | OUTER
+
+table_factor
+    : table_name
+    {
+        $$ = &TableReferenceTable{Span: newSpan(yylex, $<tok>1), TableName: $1}
+    }
+    | table_name alias
+    {
+        $$ = &TableReferenceTable{Span: newSpan(yylex, $<tok>1), TableName: $1, Alias: $2}
+    }
+    | query_parens alias
+    {
+        $$ = &TableReferenceSubquery{Span: newSpan(yylex, $<tok>1), Select: $1.Query, Alias: $2}
+    }
+    | LATERAL query_parens alias
+    {
+        $$ = &TableReferenceSubquery{Span: newSpan(yylex, $<tok>1), Lateral: true, Select: $2.Query, Alias: $3}
+    }
+    | JSON_TABLE '(' expression ',' quoted_string COLUMNS '(' json_table_columns ')' ')' alias
+    {
+        $$ = &TableReferenceJSONTable{Span: newSpan(yylex, $<tok>1), Expression: $3, Path: $5, Columns: $8, Alias: $11}
+    }
+    | '(' table_references ')'
+    {
+        $$ = &TableReferenceParen{Span: newSpan(yylex, $<tok>1), References: $2}
+    }
+
+where_clause
+    :
+    {
+        $$ = nil
+    }
+    | WHERE expression
+    {
+        $$ = $2
+    }
+
+group_by_clause
+    :
+    {
+        $$ = nil
+    }
+    | GROUP BY expressions
+    {
+        $$ = $3
+    }
+
+having_clause
+    :
+    {
+        $$ = nil
+    }
+    | HAVING expression
+    {
+        $$ = $2
+    }
+
+order_by_clause
+    :
+    {
+        $$ = nil
+    }
+    | ORDER BY order_by_items
+    {
+        $$ = $3
+    }
+
+order_by_items
+    : order_by_item
+    {
+        $$ = []OrderByItem{$1}
+    }
+    | order_by_items ',' order_by_item
+    {
+        $$ = append($1, $3)
+    }
+
+order_by_item
+    : expression
+    {
+        $$ = OrderByItem{Span: newSpan(yylex, $<tok>1), Expression: $1}
+    }
+    | expression ASC
+    {
+        $$ = OrderByItem{Span: newSpan(yylex, $<tok>1), Expression: $1}
+    }
+    | expression DESC
+    {
+        $$ = OrderByItem{Span: newSpan(yylex, $<tok>1), Expression: $1, Desc: true}
+    }
+
+limit_clause
+    :
+    {
+        $$ = nil
+    }
+    | LIMIT limit_value
+    {
+        $$ = &Limit{Span: newSpan(yylex, $<tok>1), Count: $2}
+    }
+    | LIMIT limit_value ',' limit_value
+    {
+        $$ = &Limit{Span: newSpan(yylex, $<tok>1), Offset: $2, Count: $4}
+    }
+    | LIMIT limit_value OFFSET limit_value
+    {
+        $$ = &Limit{Span: newSpan(yylex, $<tok>1), Count: $2, Offset: $4}
+    }
+
+limit_value
+    : NUMBER
+    {
+        $$ = &NumberExpression{Span: newSpan(yylex, $<tok>1), Value: $1.lit, Type: NUMBER_TYPE_INTEGER}
+    }
+    | '?'
+    {
+        $$ = newParamExpression(yylex, newSpan(yylex, $<tok>1))
+    }
+
+select_lock
+    :
+    {
+        $$ = selectLock{}
+    }
+    | FOR UPDATE lock_of lock_option
+    {
+        $$ = selectLock{lock: "FOR UPDATE", of: $3, option: $4}
+    }
+    | FOR SHARE lock_of lock_option
+    {
+        $$ = selectLock{lock: "FOR SHARE", of: $3, option: $4}
+    }
+    | LOCK IN SHARE MODE
+    {
+        $$ = selectLock{lock: "LOCK IN SHARE MODE"}
+    }
+
+lock_of
+    :
+    {
+        $$ = nil
+    }
+    | OF table_name_list
+    {
+        $$ = $2
+    }
+
+lock_option
+    :
+    {
+        $$ = ""
+    }
+    | NOWAIT
+    {
+        $$ = "NOWAIT"
+    }
+    | SKIP LOCKED
+    {
+        $$ = "SKIP LOCKED"
+    }
+
+insert_or_replace
+    : INSERT
+    {
+        $$ = false
+    }
+    | REPLACE
+    {
+        $$ = true
+    }
+
+insert_options
+    :
+    {
+        $$ = insertOptions{}
+    }
+    | insert_priority
+    {
+        $$ = insertOptions{priority: $1}
+    }
+    | IGNORE
+    {
+        $$ = insertOptions{ignore: true}
+    }
+    | insert_priority IGNORE
+    {
+        $$ = insertOptions{priority: $1, ignore: true}
+    }
+
+insert_priority
+    : LOW_PRIORITY
+    {
+        $$ = "LOW_PRIORITY"
+    }
+    | DELAYED
+    {
+        $$ = "DELAYED"
+    }
+    | HIGH_PRIORITY
+    {
+        $$ = "HIGH_PRIORITY"
+    }
+
+skipable_into
+    :
+    | INTO
+
+insert_columns
+    : %prec LOWER_THAN_PAREN
+    {
+        $$ = nil
+    }
+    | '(' ')'
+    {
+        $$ = []ColumnNameIdentifier{}
+    }
+    | '(' index_column_names ')'
+    {
+        $$ = $2
+    }
+
+insert_values
+    : values_or_value insert_rows
+    {
+        $$ = &InsertStatement{Values: $2}
+    }
+    | query_expression
+    {
+        $$ = &InsertStatement{Select: $1}
+    }
+
+values_or_value
+    : VALUES
+    | VALUE
+
+insert_rows
+    : insert_row
+    {
+        $$ = [][]Expression{$1}
+    }
+    | insert_rows ',' insert_row
+    {
+        $$ = append($1, $3)
+    }
+
+insert_row
+    : '(' ')'
+    {
+        $$ = []Expression{}
+    }
+    | '(' insert_row_values ')'
+    {
+        $$ = $2
+    }
+
+insert_row_values
+    : expression_or_default
+    {
+        $$ = []Expression{$1}
+    }
+    | insert_row_values ',' expression_or_default
+    {
+        $$ = append($1, $3)
+    }
+
+expression_or_default
+    : expression
+    {
+        $$ = $1
+    }
+    | DEFAULT
+    {
+        $$ = &DefaultExpression{Span: newSpan(yylex, $<tok>1)}
+    }
+
+on_duplicate_key_update
+    :
+    {
+        $$ = nil
+    }
+    | ON DUPLICATE KEY UPDATE assignments
+    {
+        $$ = $5
+    }
+
+assignments
+    : assignment
+    {
+        $$ = []Assignment{$1}
+    }
+    | assignments ',' assignment
+    {
+        $$ = append($1, $3)
+    }
+
+assignment
+    : column_reference '=' expression_or_default
+    {
+        $$ = Assignment{Span: newSpan(yylex, $<tok>1), Column: $1.(*ColumnExpression), Value: $3}
+    }
+
+low_priority
+    :
+    {
+        $$ = false
+    }
+    | LOW_PRIORITY
+    {
+        $$ = true
+    }
+
+ignore
+    :
+    {
+        $$ = false
+    }
+    | IGNORE
+    {
+        $$ = true
+    }
+
+delete_options
+    :
+    {
+        $$ = &DeleteStatement{}
+    }
+    | delete_options LOW_PRIORITY
+    {
+        $$ = $1
+        $$.LowPriority = true
+    }
+    | delete_options QUICK
+    {
+        $$ = $1
+        $$.Quick = true
+    }
+    | delete_options IGNORE
+    {
+        $$ = $1
+        $$.Ignore = true
+    }
+
+user_variables
+    : USER_VARIABLE
+    {
+        $$ = []Expression{&UserVariableExpression{Span: newSpan(yylex, $1), Name: $1.lit}}
+    }
+    | user_variables ',' USER_VARIABLE
+    {
+        $$ = append($1, &UserVariableExpression{Span: tokenSpan($3, $3), Name: $3.lit})
+    }
+
+explainable_statement
+    : query_expression
+    {
+        $$ = $1
+    }
+    | query_parens
+    {
+        $$ = $1
+    }
+    | insert_or_replace optimizer_hints insert_options skipable_into table_name insert_columns insert_values on_duplicate_key_update
+    {
+        stmt := $7
+        stmt.Span = newSpan(yylex, $<tok>1)
+        stmt.Replace = $1
+        stmt.Hints = $2
+        stmt.Priority = $3.priority
+        stmt.Ignore = $3.ignore
+        stmt.TableName = $5
+        stmt.Columns = $6
+        stmt.OnDuplicateKeyUpdate = $8
+        $$ = stmt
+    }
+    | insert_or_replace optimizer_hints insert_options skipable_into table_name SET assignments on_duplicate_key_update
+    {
+        $$ = &InsertStatement{Span: newSpan(yylex, $<tok>1), Replace: $1, Hints: $2, Priority: $3.priority, Ignore: $3.ignore, TableName: $5, Assignments: $7, OnDuplicateKeyUpdate: $8}
+    }
+    | UPDATE optimizer_hints low_priority ignore table_references SET assignments where_clause order_by_clause limit_clause
+    {
+        $$ = &UpdateStatement{Span: newSpan(yylex, $<tok>1), Hints: $2, LowPriority: $3, Ignore: $4, Tables: $5, Assignments: $7, Where: $8, OrderBy: $9, Limit: $10}
+    }
+    | DELETE optimizer_hints delete_options FROM table_references where_clause order_by_clause limit_clause
+    {
+        stmt := $3
+        stmt.Span = newSpan(yylex, $<tok>1)
+        stmt.Hints = $2
+        stmt.From = $5
+        stmt.Where = $6
+        stmt.OrderBy = $7
+        stmt.Limit = $8
+        $$ = stmt
+    }
+    | DELETE optimizer_hints delete_options FROM table_references USING table_references where_clause
+    {
+        tables, ok := toTableNames($5)
+        if !ok {
+            return 1
+        }
+        stmt := $3
+        stmt.Span = newSpan(yylex, $<tok>1)
+        stmt.Hints = $2
+        stmt.Tables = tables
+        stmt.From = $7
+        stmt.Where = $8
+        $$ = stmt
+    }
+
+json_table_columns
+    : json_table_column
+    {
+        $$ = []JSONTableColumn{$1}
+    }
+    | json_table_columns ',' json_table_column
+    {
+        $$ = append($1, $3)
+    }
+
+json_table_column
+    : name FOR ORDINALITY
+    {
+        $$ = JSONTableColumn{Span: newSpan(yylex, $<tok>1), Name: $1, Ordinality: true}
+    }
+    | name data_type PATH quoted_string json_table_responses
+    {
+        $$ = JSONTableColumn{Span: newSpan(yylex, $<tok>1), Name: $1, DataType: $2, Path: $4, OnEmpty: $5[0], OnError: $5[1]}
+    }
+    | name data_type EXISTS PATH quoted_string
+    {
+        $$ = JSONTableColumn{Span: newSpan(yylex, $<tok>1), Name: $1, DataType: $2, Exists: true, Path: $5}
+    }
+    | NESTED quoted_string COLUMNS '(' json_table_columns ')'
+    {
+        $$ = JSONTableColumn{Span: newSpan(yylex, $<tok>1), Path: $2, Nested: $5}
+    }
+    | NESTED PATH quoted_string COLUMNS '(' json_table_columns ')'
+    {
+        $$ = JSONTableColumn{Span: newSpan(yylex, $<tok>1), Path: $3, Nested: $6}
+    }
+
+json_table_responses
+    :
+    {
+        $$ = []string{"", ""}
+    }
+    | json_table_response ON EMPTY
+    {
+        $$ = []string{$1, ""}
+    }
+    | json_table_response ON ERROR
+    {
+        $$ = []string{"", $1}
+    }
+    | json_table_response ON EMPTY json_table_response ON ERROR
+    {
+        $$ = []string{$1, $4}
+    }
+
+json_table_response
+    : NULL
+    {
+        $$ = "NULL"
+    }
+    | ERROR
+    {
+        $$ = "ERROR"
+    }
+    | DEFAULT quoted_string
+    {
+        $$ = "DEFAULT '" + $2 + "'"
+    }
+
+explain_keyword
+    : EXPLAIN
+    | DESCRIBE
+    | DESC
+
+explain_options
+    :
+    {
+        $$ = &ExplainStatement{}
+    }
+    | ANALYZE
+    {
+        $$ = &ExplainStatement{Analyze: true}
+    }
+    | FORMAT '=' ident
+    {
+        $$ = &ExplainStatement{Format: strings.ToUpper($3.lit)}
+    }
+    | ANALYZE FORMAT '=' ident
+    {
+        $$ = &ExplainStatement{Analyze: true, Format: strings.ToUpper($4.lit)}
+    }
+
+skipable_full
+    :
+    {
+        $$ = false
+    }
+    | FULL
+    {
+        $$ = true
+    }
+
+columns_or_fields
+    : COLUMNS
+    | FIELDS
+
+index_or_indexes_or_keys
+    : INDEX
+    | INDEXES
+    | KEYS
+
+from_or_in
+    : FROM
+    | IN
+
+show_from_database
+    :
+    {
+        $$ = DatabaseNameIdentifier{}
+    }
+    | from_or_in database_name
+    {
+        $$ = $2
+    }
+
+show_filter
+    :
+    {
+        $$ = nil
+    }
+    | LIKE quoted_string
+    {
+        $$ = &ShowFilter{Span: newSpan(yylex, $<tok>1), Like: $2}
+    }
+    | WHERE expression
+    {
+        $$ = &ShowFilter{Span: newSpan(yylex, $<tok>1), Where: $2}
+    }
+
+show_scope
+    :
+    {
+        $$ = ""
+    }
+    | GLOBAL
+    {
+        $$ = "GLOBAL"
+    }
+    | SESSION
+    {
+        $$ = "SESSION"
+    }
+    | LOCAL
+    {
+        $$ = "SESSION"
+    }
+
+charset_or_character_set
+    : CHARSET
+    | CHARACTER SET
+
+skipable_equal
+    :
+    | '='
+
+index_column_names
+    : column_name
+    {
+        result := []ColumnNameIdentifier{}
+        result = append(result, $1)
+        $$ = result
+    }
+    | index_column_names ',' column_name
+    {
+        result := append($1, $3)
+        $$ = result
+    }
+
+skipable_index_type
+    :
+    | USING BTREE
+    | USING HASH
+
+table_names
+    : table_name
+    {
+        $$ = []TableNameIdentifier{$1}
+    }
+    | table_names ',' table_name
+    {
+        $$ = append([]TableNameIdentifier{$3}, $1...)
+    }
+
+table_name
+    : name
+    {
+        $$ = TableNameIdentifier{Span: newSpan(yylex, $<tok>1), Name: $1}
+    }
+    | name '.' name
+    {
+        $$ = TableNameIdentifier{Span: newSpan(yylex, $<tok>1), Database: $1, Name: $3}
+    }
+
+database_name
+    : name
+    {
+        $$ = DatabaseNameIdentifier{Span: newSpan(yylex, $<tok>1), Name: $1}
+    }
+
+alter_specifications
+    :
+    {
+        $$ = nil
+    }
+    | alter_specification
+    {
+        $$ = []AlterSpecification{$1}
+    }
+    | alter_specifications ',' alter_specification
+    {
+        $$ = append($1, $3)
+    }
+
+alter_specification
+    : ADD skipable_column column_name column_definition
+    {
+        $$ = &AlterSpecificationAddColumn{Span: newSpan(yylex, $<tok>1), ColumnName: $3, ColumnDefinition: $4}
+    }
+    | ADD index_or_key skipable_index_name skipable_index_type '(' index_column_names ')'
+    {
+        $$ = &AlterSpecificationAddIndex{Span: newSpan(yylex, $<tok>1), Name: $3, Columns: $6, Unique: false}
+    }
+    | ADD UNIQUE index_or_key skipable_index_name skipable_index_type '(' index_column_names ')'
+    {
+        $$ = &AlterSpecificationAddIndex{Span: newSpan(yylex, $<tok>1), Name: $4, Columns: $7, Unique: true}
+    }
+    | DROP index_or_key index_name
+    {
+        $$ = &AlterSpecificationDropIndex{Span: newSpan(yylex, $<tok>1), Name: $3}
+    }
+    | DROP skipable_column column_name
+    {
+        $$ = &AlterSpecificationDropColumn{Span: newSpan(yylex, $<tok>1), ColumnName: $3}
+    }
+    | ENABLE KEYS
+    {
+        $$ = &AlterSpecificationKeys{Span: newSpan(yylex, $<tok>1), Enable: true}
+    }
+    | DISABLE KEYS
+    {
+        $$ = &AlterSpecificationKeys{Span: newSpan(yylex, $<tok>1), Enable: false}
+    }
+    | alter_table_options
+    {
+        $$ = &AlterSpecificationTableOptions{Span: newSpan(yylex, $<tok>1), TableOptions: $1}
+    }
+
+// partition operations can't be combined with other alter specifications.
+alter_partition_specification
+    : ADD PARTITION '(' partition_definitions ')'
+    {
+        $$ = &AlterSpecificationAddPartition{Span: newSpan(yylex, $<tok>1), Definitions: $4}
+    }
+    | ADD PARTITION PARTITIONS number
+    {
+        $$ = &AlterSpecificationAddPartition{Span: newSpan(yylex, $<tok>1), Partitions: uint($4)}
+    }
+    | DROP PARTITION partition_names
+    {
+        $$ = &AlterSpecificationDropPartition{Span: newSpan(yylex, $<tok>1), Names: $3}
+    }
+    | TRUNCATE PARTITION partition_names
+    {
+        $$ = &AlterSpecificationTruncatePartition{Span: newSpan(yylex, $<tok>1), Names: $3}
+    }
+    | TRUNCATE PARTITION ALL
+    {
+        $$ = &AlterSpecificationTruncatePartition{Span: newSpan(yylex, $<tok>1), All: true}
+    }
+    | COALESCE PARTITION number
+    {
+        $$ = &AlterSpecificationCoalescePartition{Span: newSpan(yylex, $<tok>1), Number: uint($3)}
+    }
+    | REORGANIZE PARTITION partition_names INTO '(' partition_definitions ')'
+    {
+        $$ = &AlterSpecificationReorganizePartition{Span: newSpan(yylex, $<tok>1), Names: $3, Definitions: $6}
+    }
+    | EXCHANGE PARTITION partition_name WITH TABLE table_name
+    {
+        $$ = &AlterSpecificationExchangePartition{Span: newSpan(yylex, $<tok>1), Name: $3, TableName: $6}
+    }
+    | EXCHANGE PARTITION partition_name WITH TABLE table_name WITH VALIDATION
+    {
+        $$ = &AlterSpecificationExchangePartition{Span: newSpan(yylex, $<tok>1), Name: $3, TableName: $6}
+    }
+    | EXCHANGE PARTITION partition_name WITH TABLE table_name WITHOUT VALIDATION
+    {
+        $$ = &AlterSpecificationExchangePartition{Span: newSpan(yylex, $<tok>1), Name: $3, TableName: $6, WithoutValidation: true}
+    }
+    | REMOVE PARTITIONING
+    {
+        $$ = &AlterSpecificationRemovePartitioning{Span: newSpan(yylex, $<tok>1)}
+    }
+
+alter_table_options
+    : table_option
+    {
+        setVersionGate(yylex, $1)
+        $$ = []TableOption{$1}
+    }
+    | alter_table_options table_option
+    {
+        setVersionGate(yylex, $2)
+        $$ = append($1, $2)
+    }
+
+skipable_column
+    :
+    | COLUMN
+
+column_definition
+    : data_type nullable default autoincrement key_options column_comment
+    {
+        $$ = ColumnDefinition{Span: newSpan(yylex, $<tok>1), DataTypeDefinition: $1, Nullable: $2, AutoIncrement: $4, Default: $3}
+        $$.Gates = collectVersionGates(yylex, $$.Pos(), $$.End())
+    }
+    | data_type generated_always AS '(' expression ')' generated_storage nullable key_options column_comment
+    {
+        $$ = ColumnDefinition{Span: newSpan(yylex, $<tok>1), DataTypeDefinition: $1, Nullable: $8, Default: &DefaultDefinitionEmpty{}, Generated: $5, Stored: $7}
+        $$.Gates = collectVersionGates(yylex, $$.Pos(), $$.End())
+    }
+
+generated_always
+    :
+    | GENERATED ALWAYS
+
+generated_storage
+    :
+    {
+        $$ = false
+    }
+    | VIRTUAL
+    {
+        $$ = false
+    }
+    | STORED
+    {
+        $$ = true
+    }
+
+nullable
+    :
+    {
+        $$ = true
+    }
+    | NULL
+    {
+        $$ = true
+    }
+    | NOT NULL
+    {
+        $$ = false
+    }
+
+default
+    :
+    {
+        $$ = &DefaultDefinitionEmpty{}
+    }
+    | DEFAULT NULL
+    {
+        $$ = &DefaultDefinitionNull{Span: newSpan(yylex, $<tok>1)}
+    }
+    | DEFAULT signed_number_literal
+    {
+        $$ = &DefaultDefinitionNumber{Span: newSpan(yylex, $<tok>1), Value: *$2}
+    }
+    | DEFAULT '"' RAW '"'
+    {
+        value := DefaultDefinitionString{Span: newSpan(yylex, $<tok>1)}
+        value.Value = $3.lit
+        $$ = &value
+    }
+    | DEFAULT '\'' RAW '\''
+    {
+        value := DefaultDefinitionString{Span: newSpan(yylex, $<tok>1)}
+        value.Value = $3.lit
+        $$ = &value
+    }
+    | DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    {
+        $$ = &DefaultDefinitionCurrentTimestamp{Span: newSpan(yylex, $<tok>1), OnUpdate: true}
+    }
+    | DEFAULT CURRENT_TIMESTAMP
+    {
+        $$ = &DefaultDefinitionCurrentTimestamp{Span: newSpan(yylex, $<tok>1), OnUpdate: false}
+    }
+
+string
+    : IDENT
+    {
+        $$ = $1.lit
+    }
+    | '\'' RAW '\''
+    {
+        $$ = $2.lit
+    }
+    | '"' RAW '"'
+    {
+        $$ = $2.lit
+    }
+
+autoincrement
+    :
+    {
+        $$ = false
+    }
+    | AUTO_INCREMENT
+    {
+        $$ = true
+    }
+
+key_options
+    :
+
+column_comment
+    :
+    | COMMENT string
+
+data_type
+    : BIT
+    {
+        $$ = &DataTypeDefinitionSimple{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_BIT }
+    }
+    | data_type_number length_option unsigned_option zerofill_option
+    {
+        $$ = &DataTypeDefinitionNumber{Span: newSpan(yylex, $<tok>1), Type: $1, Length: $2, Unsigned: $3, Zerofill: $4 }
+    }
+    | data_type_fraction fraction_option unsigned_option zerofill_option
+    {
+        fraction := $2
+        $$ = &DataTypeDefinitionFraction{Span: newSpan(yylex, $<tok>1), Type: $1, Length: fraction[0], Decimals: fraction[1], Unsigned: $3, Zerofill: $4 }
+    }
+    | data_type_decimal decimal_option unsigned_option zerofill_option
+    {
+        fraction := $2
+        $$ = &DataTypeDefinitionFraction{Span: newSpan(yylex, $<tok>1), Type: $1, Length: fraction[0], Decimals: fraction[1], Unsigned: $3, Zerofill: $4 }
+    }
+    | DATE
+    {
+        $$ = &DataTypeDefinitionSimple{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_DATE }
+    }
+    | TIME
+    {
+        $$ = &DataTypeDefinitionSimple{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_TIME }
+    }
+    | TIMESTAMP
+    {
+        $$ = &DataTypeDefinitionSimple{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_TIMESTAMP }
+    }
+    | DATETIME
+    {
+        $$ = &DataTypeDefinitionSimple{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_DATETIME }
+    }
+    | YEAR
+    {
+        $$ = &DataTypeDefinitionSimple{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_YEAR }
+    }
+    | CHAR length_option optional_character_set optional_collate
+    {
+        $$ = &DataTypeDefinitionString{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_CHAR, Length: $2 }
+    }
+    | VARCHAR length_option optional_character_set optional_collate
+    {
+        $$ = &DataTypeDefinitionString{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_VARCHAR, Length: $2 }
+    }
+    | BINARY
+    {
+        $$ = &DataTypeDefinitionSimple{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_BINARY }
+    }
+    | VARBINARY
+    {
+        $$ = &DataTypeDefinitionSimple{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_VARBINARY }
+    }
+    | TINYBLOB
+    {
+        $$ = &DataTypeDefinitionSimple{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_TINYBLOB }
+    }
+    | BLOB
+    {
+        $$ = &DataTypeDefinitionSimple{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_BLOB }
+    }
+    | MEDIUMBLOB
+    {
+        $$ = &DataTypeDefinitionSimple{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_MEDIUMBLOB }
+    }
+    | LONGBLOB
+    {
+        $$ = &DataTypeDefinitionSimple{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_LONGBLOB }
+    }
+    | TINYTEXT
+    {
+        $$ = &DataTypeDefinitionTextBlob{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_TINYTEXT }
+    }
+    | TEXT optional_character_set optional_collate
+    {
+        $$ = &DataTypeDefinitionTextBlob{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_TEXT }
+    }
+    | MEDIUMTEXT optional_character_set optional_collate
+    {
+        $$ = &DataTypeDefinitionTextBlob{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_MEDIUMTEXT }
+    }
+    | LONGTEXT optional_character_set optional_collate
+    {
+        $$ = &DataTypeDefinitionTextBlob{Span: newSpan(yylex, $<tok>1), Type: DATATYPE_LONGTEXT }
+    }
+
+data_type_number
+    : TINYINT
+    {
+        $$ = DATATYPE_TINYINT
+    }
+    | SMALLINT
+    {
+        $$ = DATATYPE_SMALLINT
+    }
+    | MEDIUMINT
+    {
+        $$ = DATATYPE_MEDIUMINT
+    }
+    | INT
+    {
+        $$ = DATATYPE_INT
+    }
+    | INTEGER
+    {
+        $$ = DATATYPE_INT
+    }
+    | BIGINT
+    {
+        $$ = DATATYPE_BIGINT
+    }
+
+data_type_fraction
+    : REAL
+    {
+        $$ = DATATYPE_REAL
+    }
+    | DOUBLE
+    {
+        $$ = DATATYPE_DOUBLE
+    }
+    | FLOAT
+    {
+        $$ = DATATYPE_FLOAT
+    }
+
+data_type_decimal
+    : DECIMAL
+    {
+        $$ = DATATYPE_DECIMAL
+    }
+    | NUMERIC
+    {
+        $$ = DATATYPE_NUMERIC
+    }
+
+length_option
+    :
+    {
+        $$ = 0
+    }
+    | '(' number ')'
+    {
+        $$ = uint($2)
+    }
+
+fraction_option
+    :
+    {
+        $$ = [2]uint{0, 0}
+    }
+    | '(' number ',' number ')'
+    {
+        $$ = [2]uint{uint($2), uint($4)}
+    }
+
+optional_character_set
+    :
+    | CHARACTER SET string
+
+optional_collate
+    :
+    | COLLATE string
+
+decimal_option
+    :
+    {
+        $$ = [2]uint{0, 0}
+    }
+    | '(' number ')'
+    {
+        $$ = [2]uint{uint($2), 0}
+    }
+    | '(' number ',' number ')'
+    {
+        $$ = [2]uint{uint($2), uint($4)}
+    }
+
+unsigned_option
+    :
+    {
+        $$ = false
+    }
+    | UNSIGNED
+    {
+        $$ = true
+    }
+
+zerofill_option
+    :
+    {
+        $$ = false
+    }
+    | ZEROFILL
+    {
+        $$ = true
+    }
+
+
+index_or_key
+    : INDEX
+    | KEY
+
+column_name
+    : name
+    {
+        $$ = ColumnNameIdentifier{Span: newSpan(yylex, $<tok>1), Name: $1}
+    }
+
+skipable_index_name
+    :
+    {
+        $$ = IndexNameIdentifier{Name: ""}
+    }
+    | index_name
+    {
+        $$ = $1
+    }
+
+index_name
+    : name
+    {
+        $$ = IndexNameIdentifier{Span: newSpan(yylex, $<tok>1), Name: $1}
+    }
+
+storage_engine_name
+    : name
+    {
+        $$ = $1
+    }
+    | quoted_string
+    {
+        $$ = $1
+    }
+
+skipable_default
+    :
+    {
+        $<tok>$ = lexerToken{}
+    }
     | DEFAULT
 
+expression
+    : expression OR expression
+    {
+        $$ = &BinaryExpression{Span: newSpan(yylex, $<tok>1), Operator: "OR", Left: $1, Right: $3}
+    }
+    | expression XOR expression
+    {
+        $$ = &BinaryExpression{Span: newSpan(yylex, $<tok>1), Operator: "XOR", Left: $1, Right: $3}
+    }
+    | expression AND expression
+    {
+        $$ = &BinaryExpression{Span: newSpan(yylex, $<tok>1), Operator: "AND", Left: $1, Right: $3}
+    }
+    | NOT expression
+    {
+        $$ = newNotExpression(yylex, newSpan(yylex, $<tok>1), $2)
+    }
+    | boolean_primary
+    {
+        $$ = $1
+    }
+
+boolean_primary
+    : boolean_primary IS is_value
+    {
+        $$ = &IsExpression{Span: newSpan(yylex, $<tok>1), Expression: $1, Value: $3}
+    }
+    | boolean_primary IS NOT is_value
+    {
+        $$ = &IsExpression{Span: newSpan(yylex, $<tok>1), Expression: $1, Not: true, Value: $4}
+    }
+    | boolean_primary comparison_operator predicate
+    {
+        $$ = &BinaryExpression{Span: newSpan(yylex, $<tok>1), Operator: $2, Left: $1, Right: $3}
+    }
+    | predicate
+    {
+        $$ = $1
+    }
+
+is_value
+    : NULL
+    {
+        $$ = "NULL"
+    }
+    | TRUE
+    {
+        $$ = "TRUE"
+    }
+    | FALSE
+    {
+        $$ = "FALSE"
+    }
+    | UNKNOWN_SYM
+    {
+        $$ = "UNKNOWN"
+    }
+
+comparison_operator
+    : '='
+    {
+        $$ = "="
+    }
+    | NULL_SAFE_EQUAL
+    {
+        $$ = "<=>"
+    }
+    | '<'
+    {
+        $$ = "<"
+    }
+    | '>'
+    {
+        $$ = ">"
+    }
+    | LE
+    {
+        $$ = "<="
+    }
+    | GE
+    {
+        $$ = ">="
+    }
+    | NE
+    {
+        $$ = "<>"
+    }
+
+predicate
+    : bit_expression IN '(' expressions ')'
+    {
+        $$ = &InExpression{Span: newSpan(yylex, $<tok>1), Expression: $1, List: $4}
+    }
+    | bit_expression NOT IN '(' expressions ')'
+    {
+        $$ = &InExpression{Span: newSpan(yylex, $<tok>1), Expression: $1, Not: true, List: $5}
+    }
+    | bit_expression IN query_parens
+    {
+        $$ = &InExpression{Span: newSpan(yylex, $<tok>1), Expression: $1, Subquery: $3.Query}
+    }
+    | bit_expression NOT IN query_parens
+    {
+        $$ = &InExpression{Span: newSpan(yylex, $<tok>1), Expression: $1, Not: true, Subquery: $4.Query}
+    }
+    | bit_expression BETWEEN bit_expression AND predicate
+    {
+        $$ = &BetweenExpression{Span: newSpan(yylex, $<tok>1), Expression: $1, From: $3, To: $5}
+    }
+    | bit_expression NOT BETWEEN bit_expression AND predicate
+    {
+        $$ = &BetweenExpression{Span: newSpan(yylex, $<tok>1), Expression: $1, Not: true, From: $4, To: $6}
+    }
+    | bit_expression LIKE simple_expression %prec LIKE_WITHOUT_ESCAPE
+    {
+        $$ = &LikeExpression{Span: newSpan(yylex, $<tok>1), Expression: $1, Pattern: $3}
+    }
+    | bit_expression LIKE simple_expression ESCAPE simple_expression
+    {
+        $$ = &LikeExpression{Span: newSpan(yylex, $<tok>1), Expression: $1, Pattern: $3, Escape: $5}
+    }
+    | bit_expression NOT LIKE simple_expression %prec LIKE_WITHOUT_ESCAPE
+    {
+        $$ = &LikeExpression{Span: newSpan(yylex, $<tok>1), Expression: $1, Not: true, Pattern: $4}
+    }
+    | bit_expression NOT LIKE simple_expression ESCAPE simple_expression
+    {
+        $$ = &LikeExpression{Span: newSpan(yylex, $<tok>1), Expression: $1, Not: true, Pattern: $4, Escape: $6}
+    }
+    | bit_expression
+    {
+        $$ = $1
+    }
+
+bit_expression
+    : simple_expression
+    {
+        $$ = $1
+    }
+    | bit_expression '+' bit_expression
+    {
+        $$ = &BinaryExpression{Span: newSpan(yylex, $<tok>1), Operator: "+", Left: $1, Right: $3}
+    }
+    | bit_expression '-' bit_expression
+    {
+        $$ = &BinaryExpression{Span: newSpan(yylex, $<tok>1), Operator: "-", Left: $1, Right: $3}
+    }
+    | bit_expression '*' bit_expression
+    {
+        $$ = &BinaryExpression{Span: newSpan(yylex, $<tok>1), Operator: "*", Left: $1, Right: $3}
+    }
+    | bit_expression '/' bit_expression
+    {
+        $$ = &BinaryExpression{Span: newSpan(yylex, $<tok>1), Operator: "/", Left: $1, Right: $3}
+    }
+    | bit_expression '%' bit_expression
+    {
+        $$ = &BinaryExpression{Span: newSpan(yylex, $<tok>1), Operator: "%", Left: $1, Right: $3}
+    }
+    | bit_expression DIV bit_expression
+    {
+        $$ = &BinaryExpression{Span: newSpan(yylex, $<tok>1), Operator: "DIV", Left: $1, Right: $3}
+    }
+    | bit_expression MOD bit_expression
+    {
+        $$ = &BinaryExpression{Span: newSpan(yylex, $<tok>1), Operator: "MOD", Left: $1, Right: $3}
+    }
+    | bit_expression CONCAT_PIPES bit_expression
+    {
+        $$ = &FunctionCallExpression{Span: newSpan(yylex, $<tok>1), Name: "CONCAT", Arguments: []Expression{$1, $3}}
+    }
+    | '-' bit_expression %prec UNARY
+    {
+        $$ = &UnaryExpression{Span: newSpan(yylex, $<tok>1), Operator: "-", Expression: $2}
+    }
+    | '+' bit_expression %prec UNARY
+    {
+        $$ = &UnaryExpression{Span: newSpan(yylex, $<tok>1), Operator: "+", Expression: $2}
+    }
+
+simple_expression
+    : literal
+    {
+        $$ = $1
+    }
+    | column_reference
+    {
+        $$ = $1
+    }
+    | USER_VARIABLE
+    {
+        $$ = &UserVariableExpression{Span: newSpan(yylex, $<tok>1), Name: $1.lit}
+    }
+    | SYSTEM_VARIABLE
+    {
+        $$ = newSystemVariableExpression(newSpan(yylex, $1), $1.lit)
+    }
+    | '?'
+    {
+        $$ = newParamExpression(yylex, newSpan(yylex, $<tok>1))
+    }
+    | function_call
+    {
+        $$ = $1
+    }
+    | function_call OVER name
+    {
+        $$ = &WindowFunctionExpression{Span: newSpan(yylex, $<tok>1), Function: $1, Window: &WindowSpecification{Span: tokenSpan($<tok>3, $<tok>3), Name: $3}}
+    }
+    | function_call OVER window_specification
+    {
+        $$ = &WindowFunctionExpression{Span: newSpan(yylex, $<tok>1), Function: $1, Window: $3}
+    }
+    | '(' expression ')'
+    {
+        $$ = &ParenExpression{Span: newSpan(yylex, $<tok>1), Expression: $2}
+    }
+    | '(' expression ',' expressions ')'
+    {
+        $$ = &RowExpression{Span: newSpan(yylex, $<tok>1), Expressions: append([]Expression{$2}, $4...)}
+    }
+    | query_parens %prec SUBQUERY
+    {
+        $$ = &SubqueryExpression{Span: newSpan(yylex, $<tok>1), Select: $1.Query}
+    }
+    | EXISTS query_parens
+    {
+        $$ = &ExistsExpression{Span: newSpan(yylex, $<tok>1), Select: $2.Query}
+    }
+
+function_call
+    : function_name '(' ')'
+    {
+        if !checkFunctionSpace(yylex, $<tok>1, $<tok>2) {
+            return 1
+        }
+        $$ = &FunctionCallExpression{Span: newSpan(yylex, $<tok>1), Name: $1}
+    }
+    | function_name '(' expressions ')'
+    {
+        if !checkFunctionSpace(yylex, $<tok>1, $<tok>2) {
+            return 1
+        }
+        $$ = &FunctionCallExpression{Span: newSpan(yylex, $<tok>1), Name: $1, Arguments: $3}
+    }
+    | function_name '(' DISTINCT expressions ')'
+    {
+        if !checkFunctionSpace(yylex, $<tok>1, $<tok>2) {
+            return 1
+        }
+        $$ = &FunctionCallExpression{Span: newSpan(yylex, $<tok>1), Name: $1, Arguments: $4, Distinct: true}
+    }
+    | function_name '(' '*' ')'
+    {
+        if !checkFunctionSpace(yylex, $<tok>1, $<tok>2) {
+            return 1
+        }
+        $$ = &FunctionCallExpression{Span: newSpan(yylex, $<tok>1), Name: $1, Arguments: []Expression{&StarExpression{Span: tokenSpan($<tok>3, $<tok>3)}}}
+    }
+
+expressions
+    : expression
+    {
+        $$ = []Expression{$1}
+    }
+    | expressions ',' expression
+    {
+        $$ = append($1, $3)
+    }
+
+literal
+    : number_literal
+    {
+        $$ = $1
+    }
+    | '\'' RAW '\''
+    {
+        $$ = &StringExpression{Span: newSpan(yylex, $<tok>1), Value: $2.lit}
+    }
+    | '"' RAW '"'
+    {
+        $$ = &StringExpression{Span: newSpan(yylex, $<tok>1), Value: $2.lit}
+    }
+    | NATIONAL_INTRODUCER '\'' RAW '\''
+    {
+        $$ = &StringExpression{Span: newSpan(yylex, $<tok>1), Value: $3.lit, Introducer: "N"}
+    }
+    | NATIONAL_INTRODUCER '"' RAW '"'
+    {
+        $$ = &StringExpression{Span: newSpan(yylex, $<tok>1), Value: $3.lit, Introducer: "N"}
+    }
+    | UNDERSCORE_CHARSET '\'' RAW '\''
+    {
+        $$ = &StringExpression{Span: newSpan(yylex, $<tok>1), Value: $3.lit, Introducer: $1.lit}
+    }
+    | UNDERSCORE_CHARSET '"' RAW '"'
+    {
+        $$ = &StringExpression{Span: newSpan(yylex, $<tok>1), Value: $3.lit, Introducer: $1.lit}
+    }
+    | HEX_STRING
+    {
+        digits, ok := prefixedLiteralDigits($1.lit, "0123456789abcdefABCDEF")
+        if !ok || len(digits)%2 != 0 {
+            invalidValue(yylex, $1, "invalid hexadecimal literal")
+            return 1
+        }
+        $$ = &HexStringExpression{Span: newSpan(yylex, $<tok>1), Value: digits}
+    }
+    | BIT_STRING
+    {
+        digits, ok := prefixedLiteralDigits($1.lit, "01")
+        if !ok {
+            invalidValue(yylex, $1, "invalid bit literal")
+            return 1
+        }
+        $$ = &BitStringExpression{Span: newSpan(yylex, $<tok>1), Value: digits}
+    }
+    | NULL
+    {
+        $$ = &NullExpression{Span: newSpan(yylex, $<tok>1)}
+    }
+    | TRUE
+    {
+        $$ = &BoolExpression{Span: newSpan(yylex, $<tok>1), Value: true}
+    }
+    | FALSE
+    {
+        $$ = &BoolExpression{Span: newSpan(yylex, $<tok>1), Value: false}
+    }
+
+column_reference
+    : name
+    {
+        $$ = &ColumnExpression{Span: newSpan(yylex, $<tok>1), ColumnName: ColumnNameIdentifier{Span: tokenSpan($<tok>1, $<tok>1), Name: $1}}
+    }
+    | name '.' name
+    {
+        $$ = &ColumnExpression{Span: newSpan(yylex, $<tok>1), TableName: TableNameIdentifier{Span: tokenSpan($<tok>1, $<tok>1), Name: $1}, ColumnName: ColumnNameIdentifier{Span: tokenSpan($<tok>3, $<tok>3), Name: $3}}
+    }
+    | name '.' name '.' name
+    {
+        $$ = &ColumnExpression{Span: newSpan(yylex, $<tok>1), TableName: TableNameIdentifier{Span: tokenSpan($<tok>1, $<tok>3), Database: $1, Name: $3}, ColumnName: ColumnNameIdentifier{Span: tokenSpan($<tok>5, $<tok>5), Name: $5}}
+    }
+
+function_name
+    : ident
+    {
+        $$ = $1.lit
+    }
+    | RESERVED_KEYWORD
+    {
+        $$ = $1.lit
+    }
+    | MOD
+    {
+        $$ = $1.lit
+    }
+    | IF
+    {
+        $$ = $1.lit
+    }
+    | LEFT
+    {
+        $$ = $1.lit
+    }
+    | RIGHT
+    {
+        $$ = $1.lit
+    }
+    | INSERT
+    {
+        $$ = $1.lit
+    }
+    | REPLACE
+    {
+        $$ = $1.lit
+    }
+    | VALUES
+    {
+        $$ = $1.lit
+    }
+    | DATABASE
+    {
+        $$ = $1.lit
+    }
+    | CURRENT_TIMESTAMP
+    {
+        $$ = $1.lit
+    }
+
+name
+    : ident
+    {
+        $$ = $1.lit
+    }
+    | '`' RAW '`'
+    {
+        $$ = $2.lit
+        $<tok>$.end = $<tok>3.end
+    }
+
+ident
+    : IDENT
+    {
+        $$ = $1
+    }
+    | non_reserved_keyword
+    {
+        $$ = $1
+    }
+
+non_reserved_keyword
+    : NAMES | GLOBAL | SESSION | LOCAL | PERSIST | PERSIST_ONLY
+    | DATA | DIRECTORY | TABLESPACE | STORAGE | NODEGROUP | WITHOUT | VALIDATION
+    | PARTITIONS | SUBPARTITION | SUBPARTITIONS | PARTITIONING | ALGORITHM | LIST | COLUMNS | LESS | THAN
+    | REORGANIZE | TRUNCATE | EXCHANGE | COALESCE | REMOVE | ENABLE | DISABLE | ALWAYS
+    | AUTOEXTEND_SIZE | COMPRESSION | CONNECTION | DELAY_KEY_WRITE | ENCRYPTION | ENGINE_ATTRIBUTE | INSERT_METHOD | PACK_KEYS | PASSWORD
+    | SECONDARY_ENGINE | SECONDARY_ENGINE_ATTRIBUTE | STATS_AUTO_RECALC | STATS_PERSISTENT | STATS_SAMPLE_PAGES | DISK | MEMORY | FIRST | LAST
+    | DYNAMIC | FIXED | COMPRESSED | REDUNDANT | COMPACT
+    | USER | ROLE | IDENTIFIED | RANDOM | ADMIN | PRIVILEGES | ACCOUNT | ROUTINE | TEMPORARY | TABLES | VIEW | REPLICATION | CLIENT | SLAVE
+    | DATAFILE | UNDOFILE | LOGFILE | FILE_BLOCK_SIZE | EXTENT_SIZE | INITIAL_SIZE | MAX_SIZE | UNDO_BUFFER_SIZE | REDO_BUFFER_SIZE | WAIT | NO_WAIT
+    | ACTIVE | INACTIVE | SERVER | WRAPPER | OPTIONS | HOST | SOCKET | OWNER | PORT
+    | START | TRANSACTION | BEGIN | WORK | COMMIT | ROLLBACK | SAVEPOINT | CHAIN | CONSISTENT | SNAPSHOT | ONLY
+    | QUICK | FAST | MEDIUM | EXTENDED | CHANGED | UPGRADE
+    | FLUSH | LOGS | ERROR | GENERAL | HOSTS | RELAY | SLOW | STATUS | USER_RESOURCES | EXPORT
+    | OFFSET | SHARE | MODE | VALUE | DUPLICATE | UNKNOWN_SYM | PREPARE | EXECUTE | DEALLOCATE
+    | FORMAT | FULL | FIELDS | INDEXES | VARIABLES | PROCESSLIST | WARNINGS | ERRORS | GRANTS
+    | PRECEDING | FOLLOWING | CURRENT | PATH | ORDINALITY | NESTED | SKIP | LOCKED | NOWAIT
+    | AUTO_INCREMENT | AVG_ROW_LENGTH | BIT | BTREE | CHARSET | CHECKSUM | COMMENT | DATE | DATETIME | ENGINE | ESCAPE | HASH
+    | KEY_BLOCK_SIZE | MAX_ROWS | MIN_ROWS | NO | NONE | ROW_FORMAT | TEXT | TIME | TIMESTAMP | UNBOUNDED | YEAR
+
 %%
 
 type LexerWrapper struct {
     scanner *Scanner
+    parser  yyParser
+    recentTok   int
     recentLit   string
     recentPos   Position
+    // tokens of the current statement returned by Lex, which are used to
+    // find expected tokens.
+    chars      []int
+    expected   []string
     statements []Statement
+    // the number of placeholders in the current statement
+    paramCount int
+    // the end of the most recent token and the one before it
+    recentEnd   Position
+    previousEnd Position
+    // tokens are kept for SyntaxTree if keepTokens is true.
+    keepTokens bool
+    tokens     []SyntaxToken
+    // the token of an invalid value such as a too large number and the
+    // reason, which are set by invalidValue.
+    invalidToken  lexerToken
+    invalidReason string
+    // tokens in the executable comments inside the current statement
+    gatedTokens []gatedToken
+}
+
+// gatedToken is a token in an executable comment inside a statement such as
+// "/*!50100 ENGINE=InnoDB */". The token is claimed by the node which
+// records the comment as its version gate, or recorded as a partial version
+// gate of the enclosing column definition or statement.
+type gatedToken struct {
+    token   lexerToken
+    comment Position
+    gate    VersionGate
+    claimed bool
 }
 
 func (l *LexerWrapper) Lex(lval *yySymType) int {
     tok, lit, pos := l.scanner.Scan()
+    l.recentTok = tok
+    l.recentLit = lit
+    l.recentPos = pos
+    l.previousEnd = l.recentEnd
+    l.recentEnd = l.scanner.position()
     if tok == EOF {
+        l.chars = append(l.chars, 0)
         return 0
     }
-    lval.tok = Token{tok: tok, lit: lit, pos: pos}
-    l.recentLit = lit
-    l.recentPos = pos
+    l.chars = append(l.chars, tok)
+    if l.keepTokens {
+        l.tokens = append(l.tokens, SyntaxToken{Kind: tok, Pos: pos, End: l.recentEnd})
+    }
+    lval.tok = lexerToken{tok: tok, lit: lit, pos: pos, end: l.recentEnd, mariaDB: l.scanner.mariaDBComment}
+    if l.scanner.inVersionComment && !l.scanner.versionCommentTokens {
+        gate := VersionGate{Enclosed: true, Version: l.scanner.versionGate, MariaDB: l.scanner.mariaDBComment}
+        l.gatedTokens = append(l.gatedTokens, gatedToken{token: lval.tok, comment: l.scanner.versionCommentPos, gate: gate})
+    }
     return tok
 }
 
+// dropChars drops the tokens of the statements which have been parsed
+// except the lookahead token, since the expected tokens are found from the
+// head of the current statement as the parser does at the head of source.
+func (l *LexerWrapper) dropChars() {
+    if l.parser != nil && l.parser.Lookahead() >= 0 && len(l.chars) > 0 {
+        l.chars = append(l.chars[:0], l.chars[len(l.chars)-1])
+    } else {
+        l.chars = l.chars[:0]
+    }
+}
+
 func (l *LexerWrapper) Error(e string) {
+    l.expected = expectedTokens(l.chars)
 }
 
+// GetError returns *ParseError about the most recent token. The message is
+// the reason of the lexical error if the token is ILLEGAL.
 func (l *LexerWrapper) GetError(e string) error {
-    result := fmt.Sprintf("%s while processing near %q line %d, col: %d\n", e, l.recentLit, l.recentPos.Line, l.recentPos.Column)
-    result += fmt.Sprintf("%s\n", l.scanner.CurrentLine())
-    for i := 0; i < l.recentPos.Column-1; i++ {
-        result += fmt.Sprintf(" ")
+    if l.invalidReason != "" {
+        return &ParseError{
+            Message:        l.invalidReason,
+            Position:       l.invalidToken.pos,
+            Token:          l.invalidToken.tok,
+            Literal:        l.invalidToken.lit,
+            StatementIndex: len(l.statements),
+            Line:           l.scanner.lineAround(l.invalidToken.pos.Offset),
+        }
+    }
+    if l.recentTok == ILLEGAL {
+        e = l.scanner.illegalReason
+    }
+    return &ParseError{
+        Message:        e,
+        Position:       l.recentPos,
+        Token:          l.recentTok,
+        Literal:        l.recentLit,
+        Expected:       l.expected,
+        StatementIndex: len(l.statements),
+        Line:           l.scanner.lineAround(l.recentPos.Offset),
+    }
+}
+
+// invalidValue records that the value of tok is invalid for the reason,
+// which is reported by GetError. The action should abort the parse after
+// calling it.
+func invalidValue(yylex yyLexer, tok lexerToken, reason string) {
+    if l, isLexerWrapper := yylex.(*LexerWrapper); isLexerWrapper {
+        l.invalidToken = tok
+        l.invalidReason = reason
+    }
+}
+
+// versionGated is a node which can be enclosed by an executable comment
+// inside a statement.
+type versionGated interface {
+    Node
+    versionGate() *VersionGate
+}
+
+// setVersionGate records the executable comment which encloses the whole
+// node.
+func setVersionGate(yylex yyLexer, node versionGated) {
+    if gate, ok := claimVersionGate(yylex, node.Pos(), node.End()); ok {
+        *node.versionGate() = gate
+    }
+}
+
+// claimVersionGate returns the executable comment inside a statement which
+// encloses all tokens from start to end, and claims the tokens. It returns
+// false if the tokens are not enclosed by a single comment.
+func claimVersionGate(yylex yyLexer, start Position, end Position) (VersionGate, bool) {
+    l, isLexerWrapper := yylex.(*LexerWrapper)
+    if !isLexerWrapper {
+        return VersionGate{}, false
+    }
+    first, last := -1, -1
+    for i, t := range l.gatedTokens {
+        if t.token.pos.Offset == start.Offset {
+            first = i
+        }
+        if t.token.end.Offset == end.Offset {
+            last = i
+        }
+    }
+    if first < 0 || last < first || l.gatedTokens[first].comment != l.gatedTokens[last].comment {
+        return VersionGate{}, false
+    }
+    for i := first; i <= last; i++ {
+        l.gatedTokens[i].claimed = true
+    }
+    return l.gatedTokens[first].gate, true
+}
+
+// collectVersionGates claims the tokens from start to end in executable
+// comments which are not claimed by any node, and returns the comments as
+// partial version gates of the node.
+func collectVersionGates(yylex yyLexer, start Position, end Position) []PartialVersionGate {
+    l, isLexerWrapper := yylex.(*LexerWrapper)
+    if !isLexerWrapper {
+        return nil
+    }
+    var gates []PartialVersionGate
+    var comment Position
+    for i := range l.gatedTokens {
+        t := &l.gatedTokens[i]
+        if t.claimed || t.token.pos.Offset < start.Offset || t.token.end.Offset > end.Offset {
+            continue
+        }
+        t.claimed = true
+        if len(gates) > 0 && t.comment == comment {
+            gates[len(gates)-1].end = t.token.end
+            continue
+        }
+        comment = t.comment
+        gates = append(gates, PartialVersionGate{Span: Span{start: t.token.pos, end: t.token.end}, VersionGate: t.gate})
+    }
+    return gates
+}
+
+// recordVersionGates records the executable comments inside the statement
+// which are not claimed by any node as partial version gates of the
+// statement, so that the statement is parsed as if the comments were
+// executed.
+func recordVersionGates(yylex yyLexer, statement Statement) {
+    l, isLexerWrapper := yylex.(*LexerWrapper)
+    if !isLexerWrapper {
+        return
+    }
+    gates := statement.versionGates()
+    gates.Gates = append(gates.Gates, collectVersionGates(yylex, statement.Pos(), statement.End())...)
+    for len(l.gatedTokens) > 0 && l.gatedTokens[0].token.pos.Offset < statement.End().Offset {
+        l.gatedTokens = l.gatedTokens[1:]
+    }
+}
+
+// checkFunctionSpace reports an error if spaces are between the name of a
+// built-in function and '(' without IGNORE_SPACE, where the name is not a
+// function name but an identifier. name is the first token of the name.
+func checkFunctionSpace(yylex yyLexer, name lexerToken, paren lexerToken) bool {
+    l, isLexerWrapper := yylex.(*LexerWrapper)
+    if !isLexerWrapper || l.scanner.IgnoreSpace || name.end.Offset == paren.pos.Offset {
+        return true
+    }
+    if !ignoreSpaceFunctions[strings.ToUpper(name.lit)] {
+        return true
+    }
+    invalidValue(yylex, name, "space after built-in function name")
+    return false
+}
+
+// newSystemVariableExpression builds SystemVariableExpression from the literal
+// of SYSTEM_VARIABLE token such as "sql_mode" or "session.sql_mode".
+func newSystemVariableExpression(span Span, lit string) *SystemVariableExpression {
+    parts := strings.SplitN(lit, ".", 2)
+    if len(parts) == 1 {
+        return &SystemVariableExpression{Span: span, Name: lit}
     }
-    result += fmt.Sprintf("^\n")
-    return errors.New(result)
+    scope := strings.ToUpper(parts[0])
+    if scope == "LOCAL" {
+        scope = "SESSION"
+    }
+    return &SystemVariableExpression{Span: span, Scope: scope, Name: parts[1]}
+}
+
+// roleOrPrivilege is an item of GRANT or REVOKE. role or privilege is nil if
+// the item can't be used as it.
+type roleOrPrivilege struct {
+    // the first token of the item, which is reported if the item is not
+    // acceptable.
+    token     lexerToken
+    role      *AccountNameIdentifier
+    privilege *Privilege
+}
+
+// invalidItem records that the item is not acceptable for the reason as the
+// error about the source text of the whole item.
+func invalidItem(yylex yyLexer, item roleOrPrivilege, end Position, reason string) {
+    token := item.token
+    if l, isLexerWrapper := yylex.(*LexerWrapper); isLexerWrapper {
+        base := l.scanner.baseOffset
+        token.lit = l.scanner.src[token.pos.Offset-base : end.Offset-base]
+        token.end = end
+    }
+    invalidValue(yylex, token, reason)
+}
+
+// toPrivileges returns the privileges of items. It records the error and
+// returns false if an item is not a privilege but a role.
+func toPrivileges(yylex yyLexer, items []roleOrPrivilege) ([]Privilege, bool) {
+    var privileges []Privilege
+    for _, item := range items {
+        if item.privilege == nil {
+            invalidItem(yylex, item, item.role.End(), "role is not allowed with ON")
+            return nil, false
+        }
+        privileges = append(privileges, *item.privilege)
+    }
+    return privileges, true
+}
+
+// toRoles returns the roles of items. It records the error and returns
+// false if an item is not a role but a privilege.
+func toRoles(yylex yyLexer, items []roleOrPrivilege) ([]AccountNameIdentifier, bool) {
+    var roles []AccountNameIdentifier
+    for _, item := range items {
+        if item.role == nil {
+            invalidItem(yylex, item, item.privilege.End(), "privilege is not allowed without ON")
+            return nil, false
+        }
+        roles = append(roles, *item.role)
+    }
+    return roles, true
+}
+
+// isRevokeAll returns true if items are "ALL [PRIVILEGES], GRANT OPTION".
+func isRevokeAll(items []roleOrPrivilege) bool {
+    if len(items) != 2 || items[0].privilege == nil || items[1].privilege == nil {
+        return false
+    }
+    return items[0].privilege.Type == "ALL" && items[1].privilege.Type == "GRANT OPTION"
+}
+
+// prefixedLiteralDigits returns the digits of literal such as X'4D' or
+// B'101'. It returns false if literal is not closed or contains characters
+// other than valid.
+func prefixedLiteralDigits(literal string, valid string) (string, bool) {
+    if len(literal) < 3 || literal[len(literal)-1] != '\'' {
+        return "", false
+    }
+    digits := literal[2 : len(literal)-1]
+    for _, ch := range digits {
+        if !strings.ContainsRune(valid, ch) {
+            return "", false
+        }
+    }
+    return digits, true
+}
+
+// parseSize parses size with suffix such as "16M".
+func parseSize(number string, suffix string) (uint64, bool) {
+    size, err := strconv.ParseUint(number, 10, 64)
+    if err != nil {
+        return 0, false
+    }
+    switch strings.ToUpper(suffix) {
+    case "K":
+        return size << 10, true
+    case "M":
+        return size << 20, true
+    case "G":
+        return size << 30, true
+    }
+    return 0, false
+}
+
+type insertOptions struct {
+    priority string
+    ignore   bool
+}
+
+type selectLock struct {
+    lock   string
+    of     []TableNameIdentifier
+    option string
+}
+
+// newQueryExpression sets the clauses following the query expression body.
+// Locking clauses are allowed only for a single SELECT.
+func newQueryExpression(span Span, with *WithClause, body QueryExpression, orderBy []OrderByItem, limit *Limit, lock selectLock) (QueryExpression, bool) {
+    switch x := body.(type) {
+    case *SelectStatement:
+        x.Span = span
+        x.With = with
+        x.OrderBy = orderBy
+        x.Limit = limit
+        x.Lock = lock.lock
+        x.LockOf = lock.of
+        x.LockOption = lock.option
+    case *SetOperationStatement:
+        if lock.lock != "" {
+            return nil, false
+        }
+        x.Span = span
+        x.With = with
+        x.OrderBy = orderBy
+        x.Limit = limit
+    default:
+        return nil, false
+    }
+    return body, true
+}
+
+// newNotExpression returns NOT expression. If HighNotPrecedence is set,
+// NOT is applied to the leftmost operand of comparison or predicate, which
+// is enclosed in parentheses to keep the meaning without the SQL mode. The
+// spans of the expressions on the way are extended to start at NOT.
+func newNotExpression(yylex yyLexer, span Span, expression Expression) Expression {
+    l, isLexerWrapper := yylex.(*LexerWrapper)
+    if !isLexerWrapper || !l.scanner.HighNotPrecedence {
+        return &UnaryExpression{Span: span, Operator: "NOT", Expression: expression}
+    }
+    return applyHighNot(expression, span.start, true)
+}
+
+func applyHighNot(expression Expression, start Position, top bool) Expression {
+    switch e := expression.(type) {
+    case *BinaryExpression:
+        e.Left = applyHighNot(e.Left, start, false)
+        e.start = start
+    case *IsExpression:
+        e.Expression = applyHighNot(e.Expression, start, false)
+        e.start = start
+    case *InExpression:
+        e.Expression = applyHighNot(e.Expression, start, false)
+        e.start = start
+    case *BetweenExpression:
+        e.Expression = applyHighNot(e.Expression, start, false)
+        e.start = start
+    case *LikeExpression:
+        e.Expression = applyHighNot(e.Expression, start, false)
+        e.start = start
+    default:
+        span := Span{start: start, end: expression.End()}
+        not := &UnaryExpression{Span: span, Operator: "NOT", Expression: expression}
+        if top {
+            return not
+        }
+        return &ParenExpression{Span: span, Expression: not}
+    }
+    return expression
+}
+
+// newSpan returns the span of the rule being reduced, which starts at start
+// and ends at the last token shifted. The parser may have read the next
+// token as the lookahead.
+func newSpan(yylex yyLexer, start lexerToken) Span {
+    l, isLexerWrapper := yylex.(*LexerWrapper)
+    if !isLexerWrapper {
+        return Span{}
+    }
+    end := l.recentEnd
+    if l.parser != nil && l.parser.Lookahead() >= 0 {
+        end = l.previousEnd
+    }
+    return Span{start: start.pos, end: end}
+}
+
+// firstToken returns first unless it is the empty value of an optional
+// symbol which is omitted.
+func firstToken(first lexerToken, second lexerToken) lexerToken {
+    if first.tok == 0 {
+        return second
+    }
+    return first
 }
 
+// tokenSpan returns the span from the first token to the last token.
+func tokenSpan(first lexerToken, last lexerToken) Span {
+    return Span{start: first.pos, end: last.end}
+}
+
+// newParamExpression numbers placeholders from 1 in each statement.
+func newParamExpression(yylex yyLexer, span Span) *ParamExpression {
+    l, isLexerWrapper := yylex.(*LexerWrapper)
+    if !isLexerWrapper {
+        return &ParamExpression{Span: span}
+    }
+    l.paramCount++
+    return &ParamExpression{Span: span, Ordinal: l.paramCount}
+}
+
+// toTableNames converts target tables of multiple-table DELETE.
+func toTableNames(references []TableReference) ([]TableNameIdentifier, bool) {
+    var tableNames []TableNameIdentifier
+    for _, reference := range references {
+        table, ok := reference.(*TableReferenceTable)
+        if !ok || table.Alias != "" {
+            return nil, false
+        }
+        tableNames = append(tableNames, table.TableName)
+    }
+    return tableNames, true
+}
+
+func newTableOptionNumberOrDefault(span Span, key string, value int64) TableOption {
+    if value < 0 {
+        return &TableOptionDefault{Span: span, Key: key}
+    }
+    return &TableOptionNumber{Span: span, Key: key, Value: uint64(value)}
+}
+
+// Parse parses all statements of the source. Parse is safe for concurrent
+// use with different scanners.
 func Parse(s *Scanner) ([]Statement, error) {
     l := LexerWrapper{scanner: s}
-    if yyParse(&l) != 0 {
+    return l.parse()
+}
+
+// parserPool keeps parsers, whose stack is large, to be reused.
+var parserPool = sync.Pool{
+    New: func() interface{} {
+        return new(yyParserImpl)
+    },
+}
+
+func (l *LexerWrapper) parse() ([]Statement, error) {
+    parser := parserPool.Get().(*yyParserImpl)
+    defer func() {
+        // drop the values on the stack so that they can be collected.
+        *parser = yyParserImpl{}
+        parserPool.Put(parser)
+    }()
+    l.parser = parser
+    if parser.Parse(l) != 0 {
         return []Statement{}, l.GetError("syntax error")
     }
     return l.statements, nil
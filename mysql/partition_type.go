@@ -0,0 +1,31 @@
+package mysql
+
+type PartitionType uint
+
+const (
+	PARTITION_TYPE_HASH PartitionType = iota
+	PARTITION_TYPE_KEY
+	PARTITION_TYPE_RANGE
+	PARTITION_TYPE_RANGE_COLUMNS
+	PARTITION_TYPE_LIST
+	PARTITION_TYPE_LIST_COLUMNS
+)
+
+func (t PartitionType) String() string {
+	switch t {
+	case PARTITION_TYPE_HASH:
+		return "HASH"
+	case PARTITION_TYPE_KEY:
+		return "KEY"
+	case PARTITION_TYPE_RANGE:
+		return "RANGE"
+	case PARTITION_TYPE_RANGE_COLUMNS:
+		return "RANGE COLUMNS"
+	case PARTITION_TYPE_LIST:
+		return "LIST"
+	case PARTITION_TYPE_LIST_COLUMNS:
+		return "LIST COLUMNS"
+	default:
+		return ""
+	}
+}
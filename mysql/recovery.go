@@ -0,0 +1,135 @@
+package mysql
+
+// statementRange is the range of a statement in the source including the
+// delimiter, which is used to resynchronize after a syntax error.
+type statementRange struct {
+	start int
+	// the end of the statement text before the delimiter
+	textEnd   int
+	end       int
+	delimiter string
+	// the span of the statement text
+	span Span
+	// true if the statement is terminated by the delimiter
+	terminated bool
+}
+
+// nextStatementRange scans tokens until the delimiter of the statement.
+// It returns false at the end of the source.
+func (s *Scanner) nextStatementRange() (statementRange, bool) {
+	tok, _, pos := s.Scan()
+	if tok == EOF {
+		return statementRange{}, false
+	}
+	r := statementRange{
+		start: s.tokenOffset,
+		// DELIMITER command before the statement has been applied.
+		delimiter: s.delimiter,
+		span:      Span{start: pos},
+	}
+	for tok != ';' && tok != EOF {
+		r.textEnd = s.offset
+		r.span.end = s.position()
+		tok, _, _ = s.Scan()
+	}
+	if tok == ';' {
+		r.textEnd = s.tokenOffset
+		r.terminated = true
+	}
+	r.end = s.offset
+	return r, true
+}
+
+// scannerFor returns a scanner over r with the same settings as s. The
+// delimiter is supplied if r is not terminated at the end of the source.
+func (s *Scanner) scannerFor(r statementRange) *Scanner {
+	return &Scanner{
+		src:                s.src,
+		offset:             r.start,
+		baseOffset:         s.baseOffset,
+		line:               r.span.start.Line - 1,
+		column:             r.span.start.Column - 1,
+		end:                r.end,
+		delimiter:          r.delimiter,
+		terminateAtEnd:     !r.terminated,
+		ServerVersion:      s.ServerVersion,
+		ANSIQuotes:         s.ANSIQuotes,
+		NoBackslashEscapes: s.NoBackslashEscapes,
+		PipesAsConcat:      s.PipesAsConcat,
+		HighNotPrecedence:  s.HighNotPrecedence,
+		IgnoreSpace:        s.IgnoreSpace,
+		MariaDB:            s.MariaDB,
+	}
+}
+
+// restRange returns the range from the current position to the end of the
+// source.
+func (s *Scanner) restRange() statementRange {
+	return statementRange{
+		start:     s.offset,
+		textEnd:   s.end,
+		end:       s.end,
+		delimiter: s.delimiter,
+		span:      Span{start: s.position()},
+	}
+}
+
+// ParseWithRecovery parses statements like Parse, but doesn't stop at a
+// statement which has an error. It resynchronizes at the next delimiter,
+// and the statement is returned as UnparsedStatement with its error.
+func ParseWithRecovery(s *Scanner) ([]Statement, []*ParseError) {
+	var statements []Statement
+	var errs []*ParseError
+	for {
+		// the rest of the source is parsed at once rather than statement by
+		// statement, since each parse grows the stack of the parser again.
+		l := LexerWrapper{scanner: s.scannerFor(s.restRange())}
+		_, err := l.parse()
+		if err == nil {
+			return append(statements, l.statements...), errs
+		}
+
+		// skip the statements until the one which has the error.
+		parseError := err.(*ParseError)
+		var ranges []statementRange
+		for {
+			r, ok := s.nextStatementRange()
+			if !ok {
+				break
+			}
+			ranges = append(ranges, r)
+			if !r.terminated || parseError.Position.Offset < s.baseOffset+r.end {
+				break
+			}
+		}
+		if len(ranges) == 0 {
+			return append(statements, l.statements...), append(errs, parseError)
+		}
+		failed := ranges[len(ranges)-1]
+		if len(l.statements) == len(ranges)-1 {
+			statements = append(statements, l.statements...)
+		} else {
+			// a range may have more than one statement such as CREATE TABLE
+			// without the delimiter, so that they are parsed again.
+			for _, r := range ranges[:len(ranges)-1] {
+				statements, errs = parseRange(s, r, statements, errs)
+			}
+		}
+		parseError.StatementIndex = len(statements)
+		errs = append(errs, parseError)
+		statements = append(statements, &UnparsedStatement{Span: failed.span, Text: s.src[failed.start:failed.textEnd]})
+	}
+}
+
+// parseRange parses the statement of r and appends the result to
+// statements, or appends UnparsedStatement and the error if it has an error.
+func parseRange(s *Scanner, r statementRange, statements []Statement, errs []*ParseError) ([]Statement, []*ParseError) {
+	result, err := Parse(s.scannerFor(r))
+	if err != nil {
+		parseError := err.(*ParseError)
+		parseError.StatementIndex += len(statements)
+		errs = append(errs, parseError)
+		return append(statements, &UnparsedStatement{Span: r.span, Text: s.src[r.start:r.textEnd]}), errs
+	}
+	return append(statements, result...), errs
+}
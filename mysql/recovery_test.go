@@ -0,0 +1,97 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWithRecovery(t *testing.T) {
+	s := new(Scanner)
+	s.Init("DROP TABLE a;\nDROP TABLE 'x;y' b c;\nDROP TABLE d;\nCREATE SOMETHING /* ; */ e;\nDROP DATABASE f;")
+	statements, errs := ParseWithRecovery(s)
+	expect := []Statement{
+		&DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "a"}}},
+		&UnparsedStatement{Text: "DROP TABLE 'x;y' b c"},
+		&DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "d"}}},
+		&UnparsedStatement{Text: "CREATE SOMETHING /* ; */ e"},
+		&DropDatabaseStatement{DatabaseName: DatabaseNameIdentifier{Name: "f"}},
+	}
+	clearSpans(reflect.ValueOf(statements))
+	if !reflect.DeepEqual(statements, expect) {
+		t.Errorf("Expect %+#v, but got %+#v", expect, statements)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Expect 2 errors, but got %+#v", errs)
+	}
+	if errs[0].StatementIndex != 1 || errs[0].Position != (Position{Line: 2, Column: 12, Offset: 25}) || errs[0].Line != "DROP TABLE 'x;y' b c;" {
+		t.Errorf("Unexpected error %+#v", errs[0])
+	}
+	if errs[1].StatementIndex != 3 || errs[1].Position != (Position{Line: 4, Column: 8, Offset: 57}) || errs[1].Literal != "SOMETHING" {
+		t.Errorf("Unexpected error %+#v", errs[1])
+	}
+}
+
+func TestParseWithRecoveryStatementsInRange(t *testing.T) {
+	s := new(Scanner)
+	s.Init("CREATE TABLE a (id INT) CREATE TABLE b (id INT);\nDROP TABLE 'x';\nDROP TABLE c;")
+	statements, errs := ParseWithRecovery(s)
+	if len(statements) != 4 {
+		t.Fatalf("Expect 4 statements, but got %+#v", statements)
+	}
+	for i, name := range []string{"a", "b"} {
+		if stmt, ok := statements[i].(*CreateTableStatement); !ok || stmt.TableName.Name != name {
+			t.Errorf("Expect CREATE TABLE %s, but got %+#v", name, statements[i])
+		}
+	}
+	if stmt, ok := statements[2].(*UnparsedStatement); !ok || stmt.Text != "DROP TABLE 'x'" {
+		t.Errorf("Unexpected statement %+#v", statements[2])
+	}
+	if stmt, ok := statements[3].(*DropTableStatement); !ok || stmt.TableNames[0].Name != "c" {
+		t.Errorf("Unexpected statement %+#v", statements[3])
+	}
+	if len(errs) != 1 || errs[0].StatementIndex != 2 || errs[0].Position != (Position{Line: 2, Column: 12, Offset: 60}) {
+		t.Errorf("Unexpected errors %+#v", errs)
+	}
+}
+
+func TestParseWithRecoveryUnterminated(t *testing.T) {
+	src := "SELECT 1; SELECT 2 -- no delimiter"
+	s := new(Scanner)
+	s.Init(src)
+	statements, errs := ParseWithRecovery(s)
+	if len(statements) != 2 || len(errs) != 0 {
+		t.Fatalf("Expect 2 statements without errors, but got %+#v, %+#v", statements, errs)
+	}
+	if _, ok := statements[1].(*SelectStatement); !ok {
+		t.Errorf("Expect the last statement to be parsed, but got %+#v", statements[1])
+	}
+	if text := src[statements[1].Pos().Offset:statements[1].End().Offset]; text != "SELECT 2" {
+		t.Errorf("Unexpected span of the last statement %q", text)
+	}
+
+	s = new(Scanner)
+	s.Init("DROP TABLE a;\nDROP TABLE 'b'")
+	statements, errs = ParseWithRecovery(s)
+	if len(statements) != 2 || len(errs) != 1 || errs[0].Token != '\'' {
+		t.Errorf("Expect the error of the last statement, but got %+#v, %+#v", statements, errs)
+	}
+}
+
+func TestParseWithDelimiter(t *testing.T) {
+	src := "DROP TABLE a;\nDELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT 1; SELECT 2; END//\nDROP TABLE b//\ndelimiter ;\nDROP TABLE c;"
+	s := new(Scanner)
+	s.Init(src)
+	statements, errs := ParseWithRecovery(s)
+	expect := []Statement{
+		&DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "a"}}},
+		&UnparsedStatement{Text: "CREATE PROCEDURE p() BEGIN SELECT 1; SELECT 2; END"},
+		&DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "b"}}},
+		&DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "c"}}},
+	}
+	clearSpans(reflect.ValueOf(statements))
+	if !reflect.DeepEqual(statements, expect) || len(errs) != 1 {
+		t.Errorf("Expect %+#v, but got %+#v, %+#v", expect, statements, errs)
+	}
+
+	testScanTokens(t, new(Scanner), "DELIMITER $$\nfoo$$ ; $$", []int{IDENT, ';', INNER_SEMICOLON, ';'})
+}
@@ -0,0 +1,220 @@
+package mysql
+
+import "strings"
+
+// RawStatement is the text of a statement returned by Split.
+type RawStatement struct {
+	Span
+	// Text is the text of the statement without the delimiter and the
+	// comments around it.
+	Text string
+}
+
+// Split splits the source into statements without parsing them, so that
+// it works for statements which Parse doesn't support. Statements are
+// separated by the delimiter, which can be changed by DELIMITER command.
+// ';' in the BEGIN ... END body of CREATE PROCEDURE, FUNCTION, TRIGGER or
+// EVENT doesn't separate statements even if the delimiter is not changed.
+// A statement which consists only of comments is skipped.
+func Split(s *Scanner) []RawStatement {
+	var statements []RawStatement
+	var splitter statementSplitter
+	var start, end Position
+	for {
+		token := s.NextToken()
+		if token.Kind == TOKEN_KIND_COMMENT {
+			continue
+		}
+		if token.Kind == TOKEN_KIND_EOF || token.Kind == TOKEN_KIND_DELIMITER && splitter.isDelimiter(token) {
+			if splitter.tokens > 0 {
+				statements = append(statements, RawStatement{
+					Span: Span{start: start, end: end},
+					Text: s.src[start.Offset-s.baseOffset : end.Offset-s.baseOffset],
+				})
+			}
+			if token.Kind == TOKEN_KIND_EOF {
+				return statements
+			}
+			splitter = statementSplitter{}
+			continue
+		}
+		if splitter.tokens == 0 {
+			start = token.Pos
+		}
+		splitter.add(token)
+		end = token.End
+	}
+}
+
+// statementSplitter tracks BEGIN ... END blocks in the body of CREATE
+// PROCEDURE, FUNCTION, TRIGGER or EVENT. Words before the body such as
+// parameter names are not counted.
+type statementSplitter struct {
+	tokens int
+	// true while the statement may be CREATE of a stored program
+	compound bool
+	// the type of the object created such as "PROCEDURE"
+	object string
+	// the number of tokens of "= account" after DEFINER to be skipped
+	skip   int
+	parens int
+	// true from the end of the header until the body starts
+	beforeBody bool
+	// true if the name of a type or character set follows in the header
+	nameFollows bool
+	inBody      bool
+	// true if the next token begins a statement in the body
+	statementStart bool
+	// "BEGIN" or "CASE" of the open blocks
+	blocks []string
+	// true if the previous token is END which may close a block
+	afterEnd bool
+	// the previous word in upper case, or empty if it is not a word
+	previous string
+}
+
+// headerWords are the words which may appear between the header of a
+// stored program and its body, such as "RETURNS INT DETERMINISTIC".
+var headerWords = map[string]bool{
+	"COMMENT": true, "LANGUAGE": true, "SQL": true, "NOT": true, "DETERMINISTIC": true,
+	"CONTAINS": true, "NO": true, "READS": true, "MODIFIES": true, "DATA": true,
+	"SECURITY": true, "DEFINER": true, "INVOKER": true, "UNSIGNED": true, "SIGNED": true,
+	"ZEROFILL": true, "CHARACTER": true, "BINARY": true,
+}
+
+// nameWords are the words which are followed by a name in the header.
+var nameWords = map[string]bool{
+	"RETURNS": true, "CHARSET": true, "SET": true, "COLLATE": true, "FOLLOWS": true, "PRECEDES": true,
+}
+
+// blockWords are the words after which a statement begins in the body.
+var blockWords = map[string]bool{
+	"BEGIN": true, "THEN": true, "ELSE": true, "DO": true, "LOOP": true, "REPEAT": true,
+}
+
+// isDelimiter reports whether the delimiter token terminates the statement.
+func (p *statementSplitter) isDelimiter(token Token) bool {
+	if p.afterEnd {
+		p.afterEnd = false
+		p.pop()
+	}
+	return len(p.blocks) == 0 || token.Raw != ";"
+}
+
+func (p *statementSplitter) add(token Token) {
+	p.tokens++
+	word := ""
+	if token.Kind == TOKEN_KIND_IDENTIFIER {
+		word = strings.ToUpper(token.Value)
+	}
+	defer func() {
+		p.previous = word
+	}()
+	if p.tokens == 1 {
+		p.compound = word == "CREATE"
+		return
+	}
+	if !p.compound {
+		return
+	}
+	if !p.inBody {
+		p.addHeader(token, word)
+		return
+	}
+	if p.beforeBody {
+		p.addBeforeBody(token, word)
+		if p.beforeBody {
+			return
+		}
+	}
+	p.addBody(token, word)
+}
+
+// addHeader finds the type of the object and the end of the header.
+func (p *statementSplitter) addHeader(token Token, word string) {
+	switch {
+	case p.skip > 0:
+		p.skip--
+	case p.object == "":
+		// the type follows OR REPLACE, DEFINER = account and so on.
+		switch word {
+		case "":
+		case "OR", "REPLACE", "AGGREGATE":
+		case "DEFINER":
+			p.skip = 2
+		case "PROCEDURE", "FUNCTION", "TRIGGER", "EVENT":
+			p.object = word
+		default:
+			p.compound = false
+		}
+	case p.object == "PROCEDURE" || p.object == "FUNCTION":
+		// the body follows the parameters.
+		switch token.Raw {
+		case "(":
+			p.parens++
+		case ")":
+			p.parens--
+			p.inBody = p.parens == 0
+		}
+	case p.object == "TRIGGER":
+		p.inBody = word == "ROW" && p.previous == "EACH"
+	case p.object == "EVENT":
+		p.inBody = word == "DO"
+	}
+	p.beforeBody = p.inBody
+	p.statementStart = p.inBody
+}
+
+// addBeforeBody skips the characteristics of the stored program before
+// the body such as "RETURNS INT DETERMINISTIC".
+func (p *statementSplitter) addBeforeBody(token Token, word string) {
+	switch {
+	case p.nameFollows:
+		p.nameFollows = false
+	case nameWords[word]:
+		p.nameFollows = true
+	case headerWords[word]:
+	case token.Kind == TOKEN_KIND_STRING, token.Kind == TOKEN_KIND_NUMBER:
+	case token.Raw == "(" || token.Raw == ")" || token.Raw == ",":
+	default:
+		p.beforeBody = false
+	}
+}
+
+func (p *statementSplitter) addBody(token Token, word string) {
+	if p.afterEnd {
+		p.afterEnd = false
+		switch word {
+		case "IF", "LOOP", "WHILE", "REPEAT":
+			// END IF and so on close blocks which are not counted.
+			p.statementStart = false
+			return
+		case "CASE":
+			// END CASE closes CASE statement.
+			p.pop()
+			p.statementStart = false
+			return
+		}
+		p.pop()
+	}
+	switch {
+	case word == "BEGIN" && p.statementStart:
+		p.blocks = append(p.blocks, word)
+	case word == "CASE":
+		p.blocks = append(p.blocks, word)
+	case word == "END" && len(p.blocks) > 0:
+		if p.statementStart {
+			p.afterEnd = true
+		} else if p.blocks[len(p.blocks)-1] == "CASE" {
+			// the end of CASE expression
+			p.pop()
+		}
+	}
+	p.statementStart = token.Raw == ";" || token.Raw == ":" || blockWords[word]
+}
+
+func (p *statementSplitter) pop() {
+	if len(p.blocks) > 0 {
+		p.blocks = p.blocks[:len(p.blocks)-1]
+	}
+}
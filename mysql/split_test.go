@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testSplit(t *testing.T, src string, expect []string) {
+	s := new(Scanner)
+	s.Init(src)
+	var texts []string
+	for _, statement := range Split(s) {
+		texts = append(texts, statement.Text)
+	}
+	if !reflect.DeepEqual(texts, expect) {
+		t.Errorf("Expect Split(%q) %q, but got %q", src, expect, texts)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	testSplit(t, "SELECT 'a;b', `c;d` FROM t /* ; */ ; -- comment;\n# only comments;\n;UNKNOWN STATEMENT \"e;\"", []string{
+		"SELECT 'a;b', `c;d` FROM t",
+		"UNKNOWN STATEMENT \"e;\"",
+	})
+	testSplit(t, "DROP TABLE a;\nDELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT 1; SELECT 2; END//\nDELIMITER ;\nDROP TABLE b;", []string{
+		"DROP TABLE a",
+		"CREATE PROCEDURE p() BEGIN SELECT 1; SELECT 2; END",
+		"DROP TABLE b",
+	})
+	testSplit(t, "CREATE TRIGGER t BEFORE INSERT ON a FOR EACH ROW BEGIN IF NEW.x THEN SET NEW.y = CASE WHEN 1 THEN 2 END; END IF; lbl: BEGIN END lbl; END;\nBEGIN;\nCOMMIT;", []string{
+		"CREATE TRIGGER t BEFORE INSERT ON a FOR EACH ROW BEGIN IF NEW.x THEN SET NEW.y = CASE WHEN 1 THEN 2 END; END IF; lbl: BEGIN END lbl; END",
+		"BEGIN",
+		"COMMIT",
+	})
+	testSplit(t, "CREATE PROCEDURE p() BEGIN CASE x WHEN 1 THEN SELECT 1; END CASE; END;SELECT 'unterminated;", []string{
+		"CREATE PROCEDURE p() BEGIN CASE x WHEN 1 THEN SELECT 1; END CASE; END",
+		"SELECT 'unterminated;",
+	})
+	testSplit(t, "CREATE TABLE t (begin INT, `case` INT);\nSELECT 1;\nCREATE DEFINER=begin@localhost VIEW v AS SELECT CASE WHEN 1 THEN 2 END AS x;\nSELECT 2;", []string{
+		"CREATE TABLE t (begin INT, `case` INT)",
+		"SELECT 1",
+		"CREATE DEFINER=begin@localhost VIEW v AS SELECT CASE WHEN 1 THEN 2 END AS x",
+		"SELECT 2",
+	})
+	testSplit(t, "CREATE DEFINER=`root`@`%` PROCEDURE begin(IN begin INT) COMMENT 'x' BEGIN SELECT begin, end FROM t; END;\nSELECT 1;", []string{
+		"CREATE DEFINER=`root`@`%` PROCEDURE begin(IN begin INT) COMMENT 'x' BEGIN SELECT begin, end FROM t; END",
+		"SELECT 1",
+	})
+	testSplit(t, "CREATE FUNCTION f(begin INT) RETURNS DECIMAL(10, 2) DETERMINISTIC RETURN (SELECT begin FROM t);\nCREATE EVENT e ON SCHEDULE EVERY 1 DAY DO BEGIN DELETE FROM t; END;\nSELECT 1;", []string{
+		"CREATE FUNCTION f(begin INT) RETURNS DECIMAL(10, 2) DETERMINISTIC RETURN (SELECT begin FROM t)",
+		"CREATE EVENT e ON SCHEDULE EVERY 1 DAY DO BEGIN DELETE FROM t; END",
+		"SELECT 1",
+	})
+}
+
+func TestSplitPosition(t *testing.T) {
+	s := new(Scanner)
+	s.Init("-- ｈｅａｄ\nSELECT 'ａ';\n  DROP TABLE b")
+	statements := Split(s)
+	expect := []RawStatement{
+		{Span: Span{start: Position{2, 1, 16}, end: Position{2, 11, 28}}, Text: "SELECT 'ａ'"},
+		{Span: Span{start: Position{3, 3, 32}, end: Position{3, 15, 44}}, Text: "DROP TABLE b"},
+	}
+	if !reflect.DeepEqual(statements, expect) {
+		t.Errorf("Expect %+v, but got %+v", expect, statements)
+	}
+}
@@ -0,0 +1,135 @@
+package mysql
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// minReadSize is the minimum size of a read from the underlying reader.
+const minReadSize = 64 * 1024
+
+// StatementReader parses statements from io.Reader one by one. It reads
+// the source incrementally, so that the memory usage is bounded by the
+// largest statement rather than the whole source.
+type StatementReader struct {
+	config ParserConfig
+	reader io.Reader
+	// src is the source which has been read but not parsed yet.
+	src string
+	buf []byte
+	eof bool
+	// the state of scanner at the head of src
+	line       int
+	column     int
+	baseOffset int
+	delimiter  string
+
+	// the statements parsed but not returned yet, and the errors of
+	// UnparsedStatement in them.
+	statements []Statement
+	errs       []*ParseError
+	count      int
+}
+
+func NewStatementReader(r io.Reader, opts ...ParserOption) *StatementReader {
+	return &StatementReader{config: NewParserConfig(opts...), reader: r}
+}
+
+// Next returns the next statement. It returns io.EOF at the end of the
+// source, and the last statement may omit the delimiter. If the statement
+// has an error, Next returns *ParseError and the following call of Next
+// continues from the next statement.
+func (r *StatementReader) Next() (Statement, error) {
+	for len(r.statements) == 0 {
+		// the complete statements in src are parsed at once rather than one
+		// by one, since each parse grows the stack of the parser again.
+		s := r.scanner()
+		var consumed *Scanner
+		var last statementRange
+		for {
+			statement, ok := s.nextStatementRange()
+			if !ok || !statement.terminated && !r.eof {
+				break
+			}
+			state := *s
+			consumed, last = &state, statement
+		}
+		if consumed != nil {
+			p := r.scanner()
+			p.end = last.end
+			r.consume(consumed, last)
+			r.statements, r.errs = ParseWithRecovery(p)
+			continue
+		}
+		if r.eof {
+			return nil, io.EOF
+		}
+		// the statement continues beyond src.
+		if err := r.fill(); err != nil {
+			return nil, err
+		}
+	}
+	statement := r.statements[0]
+	r.statements = r.statements[1:]
+	if _, ok := statement.(*UnparsedStatement); ok && len(r.errs) > 0 {
+		parseError := r.errs[0]
+		r.errs = r.errs[1:]
+		parseError.StatementIndex = r.count
+		r.count++
+		return nil, parseError
+	}
+	r.count++
+	return statement, nil
+}
+
+// scanner returns a scanner over src except an incomplete rune at the
+// end, which will be completed by the next read.
+func (r *StatementReader) scanner() *Scanner {
+	s := r.config.NewScanner(r.src)
+	for i := len(r.src) - 1; !r.eof && i >= 0 && i >= len(r.src)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(r.src[i]) {
+			if !utf8.FullRuneInString(r.src[i:]) {
+				s.end = i
+			}
+			break
+		}
+	}
+	s.line = r.line
+	s.column = r.column
+	s.baseOffset = r.baseOffset
+	s.delimiter = r.delimiter
+	return s
+}
+
+// consume removes the statements until the end of statement from src. s
+// has scanned until the end of the statement.
+func (r *StatementReader) consume(s *Scanner, statement statementRange) {
+	r.line = s.line
+	r.column = s.column
+	r.baseOffset += statement.end
+	r.delimiter = s.delimiter
+	r.src = r.src[statement.end:]
+}
+
+// fill reads at least as many bytes as src has, so that a long statement
+// is scanned only a few times.
+func (r *StatementReader) fill() error {
+	size := len(r.src)
+	if size < minReadSize {
+		size = minReadSize
+	}
+	if len(r.buf) < size {
+		r.buf = make([]byte, size)
+	}
+	n, err := io.ReadFull(r.reader, r.buf[:size])
+	r.src += string(r.buf[:n])
+	switch err {
+	case nil:
+		return nil
+	case io.EOF, io.ErrUnexpectedEOF:
+		r.eof = true
+		return nil
+	default:
+		return err
+	}
+}
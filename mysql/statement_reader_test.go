@@ -0,0 +1,83 @@
+package mysql
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func readStatements(t *testing.T, r *StatementReader) ([]Statement, []error) {
+	var statements []Statement
+	var errs []error
+	for {
+		statement, err := r.Next()
+		if err == io.EOF {
+			return statements, errs
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		statements = append(statements, statement)
+	}
+}
+
+func TestStatementReader(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("-- dump\nDELIMITER //\nDROP TABLE a//\nDELIMITER ;\n")
+	for i := 0; i < 2000; i++ {
+		b.WriteString("INSERT INTO `テーブル` VALUES ('a;b', 1), (/* ; */ 'ｃ', 2);\n")
+	}
+	b.WriteString("DROP TABLE b;\n-- end\n")
+	src := b.String()
+
+	s := new(Scanner)
+	s.Init(src)
+	expect, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	statements, errs := readStatements(t, NewStatementReader(iotest.OneByteReader(strings.NewReader(src))))
+	if len(errs) != 0 {
+		t.Fatalf("Unexpected errors %+#v", errs)
+	}
+	if !reflect.DeepEqual(statements, expect) {
+		t.Errorf("Expect %d statements same as Parse, but got %d", len(expect), len(statements))
+	}
+}
+
+func TestStatementReaderError(t *testing.T) {
+	statements, errs := readStatements(t, NewStatementReader(strings.NewReader("DROP TABLE a;\nDROP TABLE 'x;y' b c;\nDROP TABLE d;")))
+	expect := []Statement{
+		&DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "a"}}},
+		&DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "d"}}},
+	}
+	clearSpans(reflect.ValueOf(statements))
+	if !reflect.DeepEqual(statements, expect) {
+		t.Errorf("Expect %+#v, but got %+#v", expect, statements)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("Expect 1 error, but got %+#v", errs)
+	}
+	parseError := errs[0].(*ParseError)
+	if parseError.StatementIndex != 1 || parseError.Position != (Position{Line: 2, Column: 12, Offset: 25}) || parseError.Line != "DROP TABLE 'x;y' b c;" {
+		t.Errorf("Unexpected error %+#v", parseError)
+	}
+}
+
+func TestStatementReaderUnterminated(t *testing.T) {
+	for _, src := range []string{"SELECT 1; SELECT 2", "SELECT 1; SELECT 2\n-- end\n", "SELECT 1;\n\n-- end\n"} {
+		s := new(Scanner)
+		s.Init(src)
+		expect, _ := ParseWithRecovery(s)
+		statements, errs := readStatements(t, NewStatementReader(iotest.OneByteReader(strings.NewReader(src))))
+		if len(errs) != 0 {
+			t.Errorf("Unexpected errors of %q: %+#v", src, errs)
+		}
+		if !reflect.DeepEqual(statements, expect) || len(statements) != strings.Count(src, "SELECT") {
+			t.Errorf("Expect %+#v of %q, but got %+#v", expect, src, statements)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package mysql
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SyntaxToken is a token of the source with the whitespace and comments
+// before it. Text is the token as written in the source, such as the
+// content of a string literal before unescaping.
+type SyntaxToken struct {
+	Kind    int
+	Leading string
+	Text    string
+	Pos     Position
+	End     Position
+}
+
+// SyntaxTree is a concrete syntax tree which keeps all tokens of the source
+// along with the parsed statements. String reproduces the source exactly
+// except for the nodes replaced by Replace.
+type SyntaxTree struct {
+	Statements []Statement
+	Tokens     []SyntaxToken
+	// Trailing is the whitespace and comments after the last token.
+	Trailing string
+
+	// replacements keyed by the index of the first token of the node
+	replacements map[int]nodeReplacement
+}
+
+type nodeReplacement struct {
+	last int
+	node Node
+}
+
+// ParseSyntaxTree parses statements like Parse, and keeps all tokens of the
+// source.
+func ParseSyntaxTree(s *Scanner) (*SyntaxTree, error) {
+	l := LexerWrapper{scanner: s, keepTokens: true}
+	statements, err := l.parse()
+	if err != nil {
+		return nil, err
+	}
+	tree := &SyntaxTree{Statements: statements, Tokens: l.tokens}
+	src := s.src
+	offset := 0
+	for i := range tree.Tokens {
+		token := &tree.Tokens[i]
+		token.Leading = src[offset:token.Pos.Offset]
+		token.Text = src[token.Pos.Offset:token.End.Offset]
+		offset = token.End.Offset
+	}
+	tree.Trailing = src[offset:]
+	return tree, nil
+}
+
+// TokenRange returns the range of tokens of node as Tokens[first:last]. It
+// returns false if node has no span in the source.
+func (t *SyntaxTree) TokenRange(node Node) (first int, last int, ok bool) {
+	start, end := node.Pos().Offset, node.End().Offset
+	first = sort.Search(len(t.Tokens), func(i int) bool {
+		return t.Tokens[i].Pos.Offset >= start
+	})
+	last = sort.Search(len(t.Tokens), func(i int) bool {
+		return t.Tokens[i].Pos.Offset >= end
+	})
+	if end <= start || last <= first {
+		return 0, 0, false
+	}
+	return first, last, true
+}
+
+// Replace makes String print replacement in place of the tokens of node.
+// The whitespace and comments before node are kept. To regenerate a node
+// which is modified in place, pass it as both node and replacement.
+func (t *SyntaxTree) Replace(node Node, replacement Node) error {
+	first, last, ok := t.TokenRange(node)
+	if !ok {
+		return errors.New("node is not in the syntax tree")
+	}
+	if t.replacements == nil {
+		t.replacements = map[int]nodeReplacement{}
+	}
+	// an outer node wins over the nodes inside it.
+	if r, ok := t.replacements[first]; ok && last < r.last {
+		return nil
+	}
+	t.replacements[first] = nodeReplacement{last: last, node: replacement}
+	return nil
+}
+
+// String returns the source with the replaced nodes regenerated.
+func (t *SyntaxTree) String() string {
+	var b strings.Builder
+	for i := 0; i < len(t.Tokens); {
+		b.WriteString(t.Tokens[i].Leading)
+		if r, ok := t.replacements[i]; ok {
+			b.WriteString(nodeText(r.node))
+			i = r.last
+			continue
+		}
+		b.WriteString(t.Tokens[i].Text)
+		i++
+	}
+	b.WriteString(t.Trailing)
+	return b.String()
+}
+
+// nodeText generates the text of node. The delimiter of statement is not
+// included since it is outside of the span.
+func nodeText(node Node) string {
+	switch x := node.(type) {
+	case Statement:
+		return strings.TrimSuffix(x.ToQuery(), ";")
+	case *UserSpecification:
+		return x.toQuery(false)
+	case interface{ ToQuery() string }:
+		return x.ToQuery()
+	}
+	// identifiers implement ToQuery with pointer receivers.
+	v := reflect.New(reflect.TypeOf(node))
+	v.Elem().Set(reflect.ValueOf(node))
+	if x, ok := v.Interface().(interface{ ToQuery() string }); ok {
+		return x.ToQuery()
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package mysql
+
+import (
+	"testing"
+)
+
+func testSyntaxTree(t *testing.T, src string) *SyntaxTree {
+	s := new(Scanner)
+	s.Init(src)
+	tree, err := ParseSyntaxTree(s)
+	if err != nil {
+		t.Fatalf("Parse failed %s", err)
+	}
+	if tree.String() != src {
+		t.Errorf("Expect %q to be reproduced, but got %q", src, tree.String())
+	}
+	return tree
+}
+
+func TestSyntaxTreeString(t *testing.T) {
+	testSyntaxTree(t, "")
+	testSyntaxTree(t, "-- migration\ncreate table `hoge` (\n\tid int unsigned  NOT NULL, -- id\n\tname varchar(255) default 'it''s'\n) ENGINE=InnoDB /* engine */ ;\n")
+	testSyntaxTree(t, "/*!40101 SET NAMES utf8 */;\nselect 名前 from t where a = \"x\\ny\" 'z';\n\n# end")
+}
+
+func TestSyntaxTreeReplace(t *testing.T) {
+	tree := testSyntaxTree(t, "-- migration\ncreate table `hoge` (\n\tid int unsigned  NOT NULL, -- id\n\tname varchar(255)\n);\ndrop table  fuga;\n")
+	create := tree.Statements[0].(*CreateTableStatement)
+	column := create.CreateDefinitions[1].(*CreateDefinitionColumn)
+	first, last, ok := tree.TokenRange(column)
+	if !ok || tree.Tokens[first].Text != "name" || tree.Tokens[last-1].Text != ")" {
+		t.Errorf("Unexpected token range %d:%d of %+v", first, last, column)
+	}
+
+	if err := tree.Replace(column.ColumnDefinition.DataTypeDefinition, &DataTypeDefinitionTextBlob{Type: DATATYPE_TEXT}); err != nil {
+		t.Fatalf("Replace failed %s", err)
+	}
+	expect := "-- migration\ncreate table `hoge` (\n\tid int unsigned  NOT NULL, -- id\n\tname TEXT\n);\ndrop table  fuga;\n"
+	if tree.String() != expect {
+		t.Errorf("Expect %q, but got %q", expect, tree.String())
+	}
+
+	if err := tree.Replace(tree.Statements[1], &DropTableStatement{TableNames: []TableNameIdentifier{TableNameIdentifier{Name: "piyo"}}}); err != nil {
+		t.Fatalf("Replace failed %s", err)
+	}
+	expect = "-- migration\ncreate table `hoge` (\n\tid int unsigned  NOT NULL, -- id\n\tname TEXT\n);\nDROP TABLE `piyo`;\n"
+	if tree.String() != expect {
+		t.Errorf("Expect %q, but got %q", expect, tree.String())
+	}
+
+	if err := tree.Replace(&DropTableStatement{}, tree.Statements[1]); err == nil {
+		t.Errorf("Expect a node which is not in the tree to be rejected")
+	}
+}
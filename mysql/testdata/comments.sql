@@ -0,0 +1,100 @@
+/*
+ * Schema of the billing service.
+ *
+ * This file is maintained by hand and applied by the migration runner in
+ * lexical order together with the other files of the directory. Every
+ * statement must be idempotent, because the runner replays the whole file
+ * when a migration is retried after a failure: tables are dropped with
+ * IF EXISTS before they are created, and data changes are written so that
+ * applying them twice leaves the same rows as applying them once.
+ *
+ * Conventions:
+ *   - primary keys are unsigned BIGINT named `id`, except for join tables
+ *     which use the composite key of both foreign keys;
+ *   - money is stored as DECIMAL(12,2) in the currency of the account and
+ *     never as FLOAT or DOUBLE, because rounding errors of binary floating
+ *     point numbers accumulate over invoices with many line items;
+ *   - timestamps are stored in UTC as DATETIME and converted to the time
+ *     zone of the account by the application, not by the server;
+ *   - every table and every non-obvious column has a COMMENT, which is
+ *     exported to the data catalog by the nightly documentation job.
+ *
+ * Before changing anything here, read the runbook of the billing service,
+ * in particular the sections about online schema changes and about the
+ * replication lag alerts which fire when a large ALTER TABLE is applied on
+ * the primary without a throttled online schema change tool.
+ */
+
+-- ---------------------------------------------------------------------------
+-- accounts
+-- ---------------------------------------------------------------------------
+-- An account is the unit of billing. A company usually has exactly one
+-- account, but resellers manage many accounts and are billed for all of
+-- them through the account marked as the parent of the others.
+-- ---------------------------------------------------------------------------
+
+DROP TABLE IF EXISTS `accounts`;
+CREATE TABLE `accounts` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT 'surrogate key of the account, never reused even after the account is deleted',
+  `parent_id` bigint unsigned DEFAULT NULL COMMENT 'the account which pays for this account; NULL when the account pays for itself, which is the case for almost all accounts except the ones managed by resellers',
+  `name` varchar(255) NOT NULL COMMENT 'display name shown on invoices; it is copied to each invoice when the invoice is issued so that renaming the account does not change invoices which were already sent',
+  `currency` char(3) NOT NULL DEFAULT 'USD' COMMENT 'ISO 4217 code of the currency in which the account is billed; changing it requires closing the current billing period first',
+  `time_zone` varchar(64) NOT NULL DEFAULT 'UTC' COMMENT 'IANA time zone name used to decide the boundaries of billing periods and to format dates on invoices',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`), # the only unique key; names are not unique on purpose
+  KEY `index_accounts_on_parent_id` (`parent_id`) -- used to list the accounts of a reseller
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='Accounts are the unit of billing. Each account has its own currency, time zone and billing period, and may be paid for by a parent account when it is managed by a reseller. Rows are never deleted: closed accounts are kept for the retention period required by the tax authorities of the countries in which the company operates, and are anonymized by the retention job afterwards.';
+
+-- ---------------------------------------------------------------------------
+-- invoices
+-- ---------------------------------------------------------------------------
+-- Invoices are immutable once issued. Corrections are made by issuing a
+-- credit note, which is an invoice with a negative total that refers to the
+-- invoice it corrects, so that the history of the account can always be
+-- reconstructed from the invoices alone.
+-- ---------------------------------------------------------------------------
+
+DROP TABLE IF EXISTS `invoices`;
+CREATE TABLE `invoices` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `account_id` bigint unsigned NOT NULL COMMENT 'the account which is billed, which is the parent account for accounts managed by a reseller',
+  `corrects_id` bigint unsigned DEFAULT NULL COMMENT 'the invoice corrected by this credit note; NULL for regular invoices',
+  `number` varchar(32) NOT NULL COMMENT 'human readable number printed on the invoice, unique per account and allocated without gaps as required by the accounting rules of several countries',
+  `total` decimal(12,2) NOT NULL DEFAULT '0.00' COMMENT 'sum of the line items including taxes, in the currency of the account at the time the invoice was issued',
+  `issued_at` datetime DEFAULT NULL COMMENT 'NULL while the invoice is a draft',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `index_invoices_on_account_id_and_number` (`account_id`,`number`),
+  KEY `index_invoices_on_corrects_id` (`corrects_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='Issued invoices and credit notes. Rows are immutable once issued_at is set; the application enforces it and the nightly audit job reports any row whose checksum changed after it was issued.';
+
+/* Seed the accounts used by the integration tests of the billing service.
+   The identifiers are fixed because the fixtures of the payment provider
+   sandbox refer to them, and the sandbox cannot be reset from the tests. */
+INSERT INTO `accounts` (`id`, `parent_id`, `name`, `currency`, `time_zone`) VALUES
+  (1, NULL, 'Example Inc.', 'USD', 'America/New_York'), -- the default test account
+  (2, NULL, 'Example GmbH', 'EUR', 'Europe/Berlin'),
+  (3, 1, 'Example Reseller Customer', 'USD', 'America/Los_Angeles'); # billed through account 1
+
+/* The totals below must match the fixtures of the sandbox; see the comment
+   above about why the identifiers cannot change. A long comment between the
+   columns and the values is rare but valid, and is kept here on purpose to
+   exercise the scanner on comments in the middle of a statement. */
+INSERT INTO `invoices` (`id`, `account_id`, `corrects_id`, `number`, `total`, `issued_at`)
+/* columns above, values below: the first invoice is regular, the second
+   one is a credit note which corrects part of the first one */
+VALUES (1, 1, NULL, 'INV-0001', 1200.00, '2024-01-31 23:59:59'),
+       (2, 1, 1, 'INV-0002', -200.00, '2024-02-05 10:00:00');
+
+SELECT /* the report of the finance team; keep the column order stable,
+          because the spreadsheet which imports it refers to columns by
+          position rather than by name */
+  a.`name`, -- account name as shown on invoices
+  i.`number`, # invoice number
+  i.`total`
+FROM `invoices` i
+JOIN `accounts` a ON a.`id` = i.`account_id` /* invoices are billed to the paying account */
+WHERE i.`issued_at` IS NOT NULL -- drafts are excluded
+ORDER BY i.`issued_at` DESC
+LIMIT 100;
+
+-- End of the billing schema.
@@ -0,0 +1,84 @@
+-- MySQL dump 10.13  Distrib 8.0.36, for Linux (x86_64)
+--
+-- Host: localhost    Database: shop
+-- ------------------------------------------------------
+-- Server version	8.0.36
+
+/*!50503 SET NAMES utf8mb4 */;
+
+--
+-- Table structure for table `line_items`
+--
+
+DROP TABLE IF EXISTS `line_items`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `line_items` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `price` decimal(10,2) NOT NULL,
+  `quantity` int unsigned NOT NULL DEFAULT '1',
+  `discount` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `subtotal` decimal(12,2) GENERATED ALWAYS AS ((`price` * `quantity`)) STORED NOT NULL,
+  `total` decimal(12,2) GENERATED ALWAYS AS (((`price` * `quantity`) - `discount`)) VIRTUAL,
+  `is_discounted` tinyint(1) GENERATED ALWAYS AS ((`discount` > 0)) VIRTUAL,
+  PRIMARY KEY (`id`),
+  KEY `index_line_items_on_total` (`total`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `contacts`
+--
+
+DROP TABLE IF EXISTS `contacts`;
+CREATE TABLE `contacts` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `first_name` varchar(50) NOT NULL,
+  `last_name` varchar(50) NOT NULL,
+  `email` varchar(255) NOT NULL,
+  `full_name` varchar(101) GENERATED ALWAYS AS (concat(`first_name`,_utf8mb4' ',`last_name`)) VIRTUAL,
+  `email_domain` varchar(255) GENERATED ALWAYS AS (substring_index(`email`,_utf8mb4'@',-(1))) STORED,
+  `email_lower` varchar(255) GENERATED ALWAYS AS (lower(`email`)) STORED COMMENT 'normalized for lookups',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `index_contacts_on_email_lower` (`email_lower`),
+  KEY `index_contacts_on_email_domain` (`email_domain`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+--
+-- Table structure for table `documents`
+--
+
+DROP TABLE IF EXISTS `documents`;
+CREATE TABLE `documents` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `body` longtext NOT NULL,
+  `title` varchar(255) GENERATED ALWAYS AS (json_unquote(json_extract(`body`,_utf8mb4'$.title'))) VIRTUAL,
+  `author_id` bigint unsigned GENERATED ALWAYS AS (json_extract(`body`,_utf8mb4'$.author.id')) STORED,
+  `word_count` int GENERATED ALWAYS AS (coalesce(json_extract(`body`,_utf8mb4'$.stats.words'),0)) VIRTUAL,
+  `published` tinyint(1) GENERATED ALWAYS AS ((json_extract(`body`,_utf8mb4'$.published_at') is not null)) VIRTUAL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_day` int GENERATED ALWAYS AS (to_days(`created_at`)) STORED,
+  PRIMARY KEY (`id`),
+  KEY `index_documents_on_author_id` (`author_id`),
+  KEY `index_documents_on_created_day` (`created_day`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+--
+-- Table structure for table `shapes`
+--
+
+DROP TABLE IF EXISTS `shapes`;
+CREATE TABLE `shapes` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `width` double NOT NULL,
+  `height` double NOT NULL,
+  `area` double AS (`width` * `height`),
+  `perimeter` double AS (2 * (`width` + `height`)) STORED,
+  `is_square` tinyint(1) AS (if(`width` = `height`, 1, 0)) VIRTUAL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+ALTER TABLE `line_items` ADD COLUMN `tax` decimal(12,2) GENERATED ALWAYS AS (round((`price` * `quantity`) * 0.1, 2)) VIRTUAL;
+ALTER TABLE `contacts` ADD COLUMN `initials` char(2) AS (concat(left(`first_name`, 1), left(`last_name`, 1))) STORED;
+
+-- Dump completed on 2024-03-04  5:06:07
@@ -0,0 +1,119 @@
+-- MySQL dump 10.13  Distrib 8.0.36, for Linux (x86_64)
+--
+-- Host: localhost    Database: metrics
+-- ------------------------------------------------------
+-- Server version	8.0.36
+
+/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;
+/*!50503 SET NAMES utf8mb4 */;
+/*!40014 SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0 */;
+
+--
+-- Table structure for table `events`
+--
+
+DROP TABLE IF EXISTS `events`;
+CREATE TABLE `events` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `kind` varchar(32) NOT NULL,
+  `payload` longtext DEFAULT NULL,
+  `created_at` datetime NOT NULL,
+  PRIMARY KEY (`id`,`created_at`),
+  KEY `index_events_on_kind` (`kind`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci
+/*!50500 PARTITION BY RANGE  COLUMNS(created_at)
+(PARTITION p202401 VALUES LESS THAN ('2024-02-01 00:00:00') ENGINE = InnoDB,
+ PARTITION p202402 VALUES LESS THAN ('2024-03-01 00:00:00') ENGINE = InnoDB,
+ PARTITION p202403 VALUES LESS THAN ('2024-04-01 00:00:00') ENGINE = InnoDB,
+ PARTITION p202404 VALUES LESS THAN ('2024-05-01 00:00:00') ENGINE = InnoDB,
+ PARTITION pmax VALUES LESS THAN (MAXVALUE) ENGINE = InnoDB) */;
+
+--
+-- Table structure for table `sessions`
+--
+
+DROP TABLE IF EXISTS `sessions`;
+CREATE TABLE `sessions` (
+  `id` int NOT NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `started_at` date NOT NULL,
+  PRIMARY KEY (`id`,`started_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci
+/*!50100 PARTITION BY RANGE (year(`started_at`))
+SUBPARTITION BY HASH (to_days(`started_at`))
+SUBPARTITIONS 2
+(PARTITION p2022 VALUES LESS THAN (2023) ENGINE = InnoDB,
+ PARTITION p2023 VALUES LESS THAN (2024) ENGINE = InnoDB,
+ PARTITION p2024 VALUES LESS THAN MAXVALUE ENGINE = InnoDB) */;
+
+--
+-- Table structure for table `stores`
+--
+
+DROP TABLE IF EXISTS `stores`;
+CREATE TABLE `stores` (
+  `id` int NOT NULL,
+  `region` int NOT NULL,
+  `name` varchar(100) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`,`region`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci
+/*!50100 PARTITION BY LIST (`region`)
+(PARTITION p_north VALUES IN (1,2,3) ENGINE = InnoDB,
+ PARTITION p_east VALUES IN (4,5,6) ENGINE = InnoDB,
+ PARTITION p_south VALUES IN (7,8,9) ENGINE = InnoDB,
+ PARTITION p_west VALUES IN (10,11,12) ENGINE = InnoDB) */;
+
+--
+-- Table structure for table `customers`
+--
+
+DROP TABLE IF EXISTS `customers`;
+CREATE TABLE `customers` (
+  `id` int NOT NULL,
+  `country` char(2) NOT NULL,
+  `email` varchar(255) NOT NULL,
+  PRIMARY KEY (`id`,`country`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci
+/*!50500 PARTITION BY LIST  COLUMNS(country)
+(PARTITION p_asia VALUES IN ('JP','KR','CN','TW') ENGINE = InnoDB,
+ PARTITION p_europe VALUES IN ('DE','FR','GB','IT','ES') ENGINE = InnoDB,
+ PARTITION p_america VALUES IN ('US','CA','MX','BR') ENGINE = InnoDB) */;
+
+--
+-- Table structure for table `page_views`
+--
+
+DROP TABLE IF EXISTS `page_views`;
+CREATE TABLE `page_views` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `path` varchar(255) NOT NULL,
+  `viewed_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci
+/*!50100 PARTITION BY LINEAR KEY (id)
+PARTITIONS 8 */;
+
+--
+-- Table structure for table `cache_entries`
+--
+
+DROP TABLE IF EXISTS `cache_entries`;
+CREATE TABLE `cache_entries` (
+  `id` int NOT NULL,
+  `value` text,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci
+/*!50100 PARTITION BY LINEAR HASH (`id`)
+PARTITIONS 16 */;
+
+ALTER TABLE `events` ADD PARTITION (PARTITION p202405 VALUES LESS THAN ('2024-06-01 00:00:00'));
+ALTER TABLE `events` DROP PARTITION p202401;
+ALTER TABLE `events` TRUNCATE PARTITION p202402, p202403;
+ALTER TABLE `page_views` COALESCE PARTITION 4;
+ALTER TABLE `stores` REORGANIZE PARTITION p_north INTO (PARTITION p_north1 VALUES IN (1,2), PARTITION p_north2 VALUES IN (3));
+ALTER TABLE `cache_entries` REMOVE PARTITIONING;
+
+/*!40014 SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS */;
+/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;
+
+-- Dump completed on 2024-05-01  0:00:00
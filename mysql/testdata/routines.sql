@@ -0,0 +1,112 @@
+-- MySQL dump 10.13  Distrib 8.0.36, for Linux (x86_64)
+--
+-- Host: localhost    Database: shop
+-- ------------------------------------------------------
+-- Server version	8.0.36
+
+/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;
+/*!50503 SET NAMES utf8mb4 */;
+/*!40014 SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0 */;
+/*!40101 SET @OLD_SQL_MODE=@@SQL_MODE, SQL_MODE='NO_AUTO_VALUE_ON_ZERO' */;
+
+--
+-- Table structure for table `orders`
+--
+
+DROP TABLE IF EXISTS `orders`;
+CREATE TABLE `orders` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `state` varchar(16) NOT NULL DEFAULT 'cart',
+  `total` decimal(12,2) NOT NULL DEFAULT '0.00',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `index_orders_on_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+--
+-- Dumping data for table `orders`
+--
+
+LOCK TABLES `orders` WRITE;
+/*!40000 ALTER TABLE `orders` DISABLE KEYS */;
+INSERT INTO `orders` VALUES (1,1,'paid',120.00,'2024-01-01 00:00:00'),(2,2,'cart',0.00,'2024-01-02 00:00:00');
+/*!40000 ALTER TABLE `orders` ENABLE KEYS */;
+UNLOCK TABLES;
+/*!50003 SET @saved_cs_client      = @@character_set_client */ ;
+/*!50003 SET @saved_cs_results     = @@character_set_results */ ;
+/*!50003 SET @saved_col_connection = @@collation_connection */ ;
+/*!50003 SET character_set_client  = utf8mb4 */ ;
+/*!50003 SET character_set_results = utf8mb4 */ ;
+/*!50003 SET collation_connection  = utf8mb4_0900_ai_ci */ ;
+/*!50003 SET @saved_sql_mode       = @@sql_mode */ ;
+/*!50003 SET sql_mode              = 'ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_ENGINE_SUBSTITUTION' */ ;
+DELIMITER ;;
+/*!50003 CREATE*/ /*!50017 DEFINER=`root`@`localhost`*/ /*!50003 TRIGGER `orders_before_update` BEFORE UPDATE ON `orders` FOR EACH ROW BEGIN
+  IF NEW.state <> OLD.state THEN
+    INSERT INTO order_state_changes (order_id, from_state, to_state) VALUES (OLD.id, OLD.state, NEW.state);
+  END IF;
+END */;;
+DELIMITER ;
+/*!50003 SET sql_mode              = @saved_sql_mode */ ;
+/*!50003 SET character_set_client  = @saved_cs_client */ ;
+/*!50003 SET character_set_results = @saved_cs_results */ ;
+/*!50003 SET collation_connection  = @saved_col_connection */ ;
+
+--
+-- Dumping routines for database 'shop'
+--
+/*!50003 DROP FUNCTION IF EXISTS `order_total` */;
+/*!50003 SET @saved_cs_client      = @@character_set_client */ ;
+/*!50003 SET character_set_client  = utf8mb4 */ ;
+DELIMITER ;;
+CREATE DEFINER=`root`@`localhost` FUNCTION `order_total`(p_order_id BIGINT) RETURNS decimal(12,2)
+    READS SQL DATA
+BEGIN
+  DECLARE v_total DECIMAL(12,2) DEFAULT 0;
+  SELECT COALESCE(SUM(quantity * unit_price), 0) INTO v_total
+    FROM order_items
+   WHERE order_id = p_order_id;
+  RETURN v_total;
+END ;;
+DELIMITER ;
+/*!50003 SET character_set_client  = @saved_cs_client */ ;
+/*!50003 DROP PROCEDURE IF EXISTS `close_stale_carts` */;
+DELIMITER ;;
+CREATE DEFINER=`root`@`localhost` PROCEDURE `close_stale_carts`(IN p_days INT, OUT p_closed INT)
+BEGIN
+  DECLARE done INT DEFAULT FALSE;
+  DECLARE v_id BIGINT;
+  DECLARE cur CURSOR FOR SELECT id FROM orders WHERE state = 'cart' AND updated_at < NOW() - INTERVAL p_days DAY;
+  DECLARE CONTINUE HANDLER FOR NOT FOUND SET done = TRUE;
+  SET p_closed = 0;
+  OPEN cur;
+  read_loop: LOOP
+    FETCH cur INTO v_id;
+    IF done THEN
+      LEAVE read_loop;
+    END IF;
+    UPDATE orders SET state = 'abandoned' WHERE id = v_id;
+    SET p_closed = p_closed + 1;
+  END LOOP;
+  CLOSE cur;
+END ;;
+DELIMITER ;
+/*!50003 DROP PROCEDURE IF EXISTS `recalculate_totals` */;
+DELIMITER ;;
+CREATE DEFINER=`root`@`localhost` PROCEDURE `recalculate_totals`()
+    MODIFIES SQL DATA
+    COMMENT 'recalculates the cached totals of all orders; run after a bulk import'
+BEGIN
+  UPDATE orders o
+     SET o.total = (SELECT COALESCE(SUM(i.quantity * i.unit_price), 0) FROM order_items i WHERE i.order_id = o.id);
+  SELECT ROW_COUNT() AS updated;
+END ;;
+DELIMITER ;
+/*!50003 SET sql_mode              = @saved_sql_mode */ ;
+
+/*!40101 SET SQL_MODE=@OLD_SQL_MODE */;
+/*!40014 SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS */;
+/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;
+
+-- Dump completed on 2024-03-04  5:06:07
@@ -0,0 +1,169 @@
+package mysql
+
+type TokenKind uint
+
+const (
+	TOKEN_KIND_EOF TokenKind = iota
+	TOKEN_KIND_ILLEGAL
+	TOKEN_KIND_IDENTIFIER
+	TOKEN_KIND_QUOTED_IDENTIFIER
+	TOKEN_KIND_STRING
+	TOKEN_KIND_NUMBER
+	TOKEN_KIND_VARIABLE
+	TOKEN_KIND_SYMBOL
+	TOKEN_KIND_DELIMITER
+	TOKEN_KIND_COMMENT
+	TOKEN_KIND_OPTIMIZER_HINT
+	TOKEN_KIND_EXECUTABLE_COMMENT
+)
+
+func (k TokenKind) String() string {
+	switch k {
+	case TOKEN_KIND_EOF:
+		return "EOF"
+	case TOKEN_KIND_ILLEGAL:
+		return "ILLEGAL"
+	case TOKEN_KIND_IDENTIFIER:
+		return "IDENTIFIER"
+	case TOKEN_KIND_QUOTED_IDENTIFIER:
+		return "QUOTED_IDENTIFIER"
+	case TOKEN_KIND_STRING:
+		return "STRING"
+	case TOKEN_KIND_NUMBER:
+		return "NUMBER"
+	case TOKEN_KIND_VARIABLE:
+		return "VARIABLE"
+	case TOKEN_KIND_SYMBOL:
+		return "SYMBOL"
+	case TOKEN_KIND_DELIMITER:
+		return "DELIMITER"
+	case TOKEN_KIND_COMMENT:
+		return "COMMENT"
+	case TOKEN_KIND_OPTIMIZER_HINT:
+		return "OPTIMIZER_HINT"
+	case TOKEN_KIND_EXECUTABLE_COMMENT:
+		return "EXECUTABLE_COMMENT"
+	default:
+		return ""
+	}
+}
+
+// Token is a token returned by Scanner.NextToken. Unlike Scanner.Scan, a
+// quoted string or identifier is a single token.
+type Token struct {
+	Kind TokenKind
+	// Value is the decoded value of the token, such as the content of a
+	// string without quotes and escapes, the name of a variable without
+	// '@' or the text of a comment without delimiters.
+	Value string
+	// Raw is the text of the token as written in the source.
+	Raw string
+	Pos Position
+	End Position
+	// Keyword is true if the token is a keyword such as SELECT, which may
+	// also be used as an identifier.
+	Keyword bool
+}
+
+// NextToken returns the next token including comments. It returns a token
+// of TOKEN_KIND_EOF at the end of the source. DELIMITER commands of mysql
+// client are not returned as tokens, but change the delimiter which is
+// returned as TOKEN_KIND_DELIMITER.
+//
+// NextToken and Scan should not be used together on the same Scanner.
+func (s *Scanner) NextToken() Token {
+	if len(s.pendingTokens) == 0 {
+		s.tokenizing = true
+		s.pendingTokens = append(s.pendingTokens, s.scanToken())
+		s.tokenizing = false
+	}
+	token := s.pendingTokens[0]
+	s.pendingTokens = s.pendingTokens[1:]
+	return token
+}
+
+// Tokenize returns all tokens of the source until EOF, which is not
+// included.
+func Tokenize(s *Scanner) []Token {
+	var tokens []Token
+	for {
+		token := s.NextToken()
+		if token.Kind == TOKEN_KIND_EOF {
+			return tokens
+		}
+		tokens = append(tokens, token)
+	}
+}
+
+func (s *Scanner) scanToken() Token {
+	tok, lit, pos := s.Scan()
+	start := s.tokenOffset
+	token := Token{Value: lit, Pos: pos}
+	switch tok {
+	case EOF:
+		token.Kind = TOKEN_KIND_EOF
+	case ILLEGAL:
+		token.Kind = TOKEN_KIND_ILLEGAL
+	case '\'', '"', '`':
+		token.Kind = TOKEN_KIND_STRING
+		if tok == '`' {
+			token.Kind = TOKEN_KIND_QUOTED_IDENTIFIER
+		}
+		// the content and the closing quote follow the opening quote.
+		tok, lit, _ = s.Scan()
+		if tok != RAW {
+			token.Kind = TOKEN_KIND_ILLEGAL
+			token.Value = lit
+			break
+		}
+		token.Value = lit
+		s.Scan()
+	case IDENT, NATIONAL_INTRODUCER, UNDERSCORE_CHARSET:
+		token.Kind = TOKEN_KIND_IDENTIFIER
+	case RESERVED_KEYWORD:
+		token.Kind = TOKEN_KIND_IDENTIFIER
+		token.Keyword = true
+	case NUMBER, DECIMAL_NUMBER, FLOAT_NUMBER, HEX_NUMBER, BIT_NUMBER, HEX_STRING, BIT_STRING:
+		token.Kind = TOKEN_KIND_NUMBER
+	case USER_VARIABLE, SYSTEM_VARIABLE:
+		token.Kind = TOKEN_KIND_VARIABLE
+	case ';':
+		token.Kind = TOKEN_KIND_DELIMITER
+	case COMMENT_TEXT:
+		token.Kind = TOKEN_KIND_COMMENT
+	case OPTIMIZER_HINT:
+		token.Kind = TOKEN_KIND_OPTIMIZER_HINT
+	case VERSION_COMMENT_START, COMMENT_FINISH:
+		token.Kind = TOKEN_KIND_EXECUTABLE_COMMENT
+	case INNER_SEMICOLON, LE, GE, NE, NULL_SAFE_EQUAL, CONCAT_PIPES:
+		token.Kind = TOKEN_KIND_SYMBOL
+	default:
+		// || is scanned as OR unless PipesAsConcat is set.
+		if tok < 0x80 || lit == "||" {
+			token.Kind = TOKEN_KIND_SYMBOL
+		} else {
+			token.Kind = TOKEN_KIND_IDENTIFIER
+			token.Keyword = true
+		}
+	}
+	if token.Kind != TOKEN_KIND_EOF {
+		token.Raw = s.src[start:s.offset]
+	}
+	token.End = s.position()
+	return token
+}
+
+// skipToken records the text from start to the current offset, which is
+// invisible to the parser, as a token for NextToken.
+func (s *Scanner) skipToken(kind TokenKind, value string, start int, pos Position) {
+	if !s.tokenizing {
+		return
+	}
+	s.pendingTokens = append(s.pendingTokens, Token{
+		Kind:  kind,
+		Value: value,
+		Raw:   s.src[start:s.offset],
+		Pos:   pos,
+		End:   s.position(),
+	})
+}
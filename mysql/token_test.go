@@ -0,0 +1,82 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testTokenize(t *testing.T, s *Scanner, src string, expect []Token) {
+	s.Init(src)
+	tokens := Tokenize(s)
+	for i := range tokens {
+		tokens[i].Pos = Position{}
+		tokens[i].End = Position{}
+	}
+	if !reflect.DeepEqual(tokens, expect) {
+		t.Errorf("Expect Tokenize(%q) %+v, but got %+v", src, expect, tokens)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	testTokenize(t, new(Scanner), "SELECT `a``b`, 'it''s' FROM tbl -- hoge\nWHERE id >= 1 || OR 2;", []Token{
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "SELECT", Raw: "SELECT", Keyword: true},
+		{Kind: TOKEN_KIND_QUOTED_IDENTIFIER, Value: "a`b", Raw: "`a``b`"},
+		{Kind: TOKEN_KIND_SYMBOL, Value: ",", Raw: ","},
+		{Kind: TOKEN_KIND_STRING, Value: "it's", Raw: "'it''s'"},
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "FROM", Raw: "FROM", Keyword: true},
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "tbl", Raw: "tbl"},
+		{Kind: TOKEN_KIND_COMMENT, Value: " hoge", Raw: "-- hoge"},
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "WHERE", Raw: "WHERE", Keyword: true},
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "id", Raw: "id"},
+		{Kind: TOKEN_KIND_SYMBOL, Value: ">=", Raw: ">="},
+		{Kind: TOKEN_KIND_NUMBER, Value: "1", Raw: "1"},
+		{Kind: TOKEN_KIND_SYMBOL, Value: "||", Raw: "||"},
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "OR", Raw: "OR", Keyword: true},
+		{Kind: TOKEN_KIND_NUMBER, Value: "2", Raw: "2"},
+		{Kind: TOKEN_KIND_DELIMITER, Value: ";", Raw: ";"},
+	})
+	testTokenize(t, &Scanner{ANSIQuotes: true}, "SET @a = \"tbl\", @@session.sql_mode = 'a\\n' 'b'", []Token{
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "SET", Raw: "SET", Keyword: true},
+		{Kind: TOKEN_KIND_VARIABLE, Value: "a", Raw: "@a"},
+		{Kind: TOKEN_KIND_SYMBOL, Value: "=", Raw: "="},
+		{Kind: TOKEN_KIND_QUOTED_IDENTIFIER, Value: "tbl", Raw: "\"tbl\""},
+		{Kind: TOKEN_KIND_SYMBOL, Value: ",", Raw: ","},
+		{Kind: TOKEN_KIND_VARIABLE, Value: "session.sql_mode", Raw: "@@session.sql_mode"},
+		{Kind: TOKEN_KIND_SYMBOL, Value: "=", Raw: "="},
+		{Kind: TOKEN_KIND_STRING, Value: "a\nb", Raw: "'a\\n' 'b'"},
+	})
+	testTokenize(t, new(Scanner), "ENGINE=InnoDB /*!50100 ROW_FORMAT=COMPACT */", []Token{
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "ENGINE", Raw: "ENGINE", Keyword: true},
+		{Kind: TOKEN_KIND_SYMBOL, Value: "=", Raw: "="},
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "InnoDB", Raw: "InnoDB"},
+		{Kind: TOKEN_KIND_EXECUTABLE_COMMENT, Value: "50100", Raw: "/*!50100"},
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "ROW_FORMAT", Raw: "ROW_FORMAT", Keyword: true},
+		{Kind: TOKEN_KIND_SYMBOL, Value: "=", Raw: "="},
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "COMPACT", Raw: "COMPACT", Keyword: true},
+		{Kind: TOKEN_KIND_EXECUTABLE_COMMENT, Value: "*/", Raw: "*/"},
+	})
+	testTokenize(t, new(Scanner), "SELECT /*+ BKA(t1) */ 'abc", []Token{
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "SELECT", Raw: "SELECT", Keyword: true},
+		{Kind: TOKEN_KIND_OPTIMIZER_HINT, Value: " BKA(t1) ", Raw: "/*+ BKA(t1) */"},
+		{Kind: TOKEN_KIND_ILLEGAL, Value: "'abc", Raw: "'abc"},
+	})
+}
+
+func TestNextTokenPosition(t *testing.T) {
+	s := new(Scanner)
+	s.Init("SELECT\n  'ｂ' /* c */")
+	expect := []Token{
+		{Kind: TOKEN_KIND_IDENTIFIER, Value: "SELECT", Raw: "SELECT", Pos: Position{1, 1, 0}, End: Position{1, 7, 6}, Keyword: true},
+		{Kind: TOKEN_KIND_STRING, Value: "ｂ", Raw: "'ｂ'", Pos: Position{2, 3, 9}, End: Position{2, 6, 14}},
+		{Kind: TOKEN_KIND_COMMENT, Value: " c ", Raw: "/* c */", Pos: Position{2, 7, 15}, End: Position{2, 14, 22}},
+		{Kind: TOKEN_KIND_EOF, Pos: Position{2, 14, 22}, End: Position{2, 14, 22}},
+	}
+	for _, e := range expect {
+		if token := s.NextToken(); token != e {
+			t.Errorf("Expect %+v, but got %+v", e, token)
+		}
+	}
+	if kind := TOKEN_KIND_QUOTED_IDENTIFIER.String(); kind != "QUOTED_IDENTIFIER" {
+		t.Errorf("Expect QUOTED_IDENTIFIER, but got %q", kind)
+	}
+}